@@ -0,0 +1,74 @@
+// Package auth authenticates the requests to the GraphQL endpoint.
+//
+// Clients authenticate with a JWT in the Authorization header ("Bearer
+// <token>") signed with HS256 or RS256, or with a static API key in the
+// X-API-Key header. The resulting Principal is stored in the request context.
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// Role is a permission granted to a principal.
+type Role string
+
+// Roles known to the application.
+const (
+	RoleAdmin  Role = "ADMIN"
+	RoleEditor Role = "EDITOR"
+)
+
+// Principal is the authenticated identity making a request.
+type Principal struct {
+	// Subject identifies the principal, it is empty for anonymous requests.
+	Subject string
+	Roles   []Role
+	// AgentID is the agent the principal acts as, if any.
+	AgentID *int64
+}
+
+// Anonymous is the principal of unauthenticated requests.
+var Anonymous = &Principal{}
+
+// IsAnonymous reports whether the principal is unauthenticated.
+func (p *Principal) IsAnonymous() bool {
+	return p.Subject == ""
+}
+
+// HasRole reports whether the principal has been granted role. Admins are
+// granted every role.
+func (p *Principal) HasRole(role Role) bool {
+	for _, r := range p.Roles {
+		if r == role || r == RoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrUnauthenticated is returned when a request carries invalid credentials,
+// or none while anonymous requests are rejected.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ErrForbidden is returned when the principal lacks the permission needed to
+// access a field.
+var ErrForbidden = errors.New("forbidden")
+
+type contextKey string
+
+const principalKey = contextKey("principal")
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// ForContext returns the principal of the request, Anonymous if there is
+// none.
+func ForContext(ctx context.Context) *Principal {
+	if p, ok := ctx.Value(principalKey).(*Principal); ok {
+		return p
+	}
+	return Anonymous
+}
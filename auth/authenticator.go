@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/handler"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// APIKeyHeader is the header carrying static API keys.
+const APIKeyHeader = "X-API-Key"
+
+// Options configures an Authenticator. The key files are optional, but at
+// least one of them is needed for any request to be authenticated.
+type Options struct {
+	// HS256SecretFile contains the shared secret of HS256 tokens.
+	HS256SecretFile string
+	// RS256PublicKeyFile contains the PEM encoded public key of RS256 tokens.
+	RS256PublicKeyFile string
+	// JWKSFile contains a JSON Web Key Set with RSA and symmetric keys,
+	// selected by the kid header of the tokens.
+	JWKSFile string
+	// APIKeysFile contains a JSON array of API keys and their principals.
+	APIKeysFile string
+	// Issuer and Audience, if set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// RejectAnonymous makes requests without credentials fail instead of
+	// proceeding with the Anonymous principal.
+	RejectAnonymous bool
+}
+
+// Authenticator verifies the credentials of requests.
+type Authenticator struct {
+	hmacSecret      []byte
+	rsaKey          *rsa.PublicKey
+	jwks            map[string]interface{}
+	apiKeys         []apiKey
+	issuer          string
+	audience        string
+	rejectAnonymous bool
+}
+
+type apiKey struct {
+	hash      [sha256.Size]byte
+	principal *Principal
+}
+
+// New returns an Authenticator reading its keys from the files given in
+// opts.
+func New(opts Options) (*Authenticator, error) {
+	a := &Authenticator{
+		issuer:          opts.Issuer,
+		audience:        opts.Audience,
+		rejectAnonymous: opts.RejectAnonymous,
+	}
+	if opts.HS256SecretFile != "" {
+		b, err := ioutil.ReadFile(opts.HS256SecretFile)
+		if err != nil {
+			return nil, fmt.Errorf("auth: %v", err)
+		}
+		a.hmacSecret = []byte(strings.TrimSpace(string(b)))
+		if len(a.hmacSecret) == 0 {
+			return nil, fmt.Errorf("auth: %s: empty secret", opts.HS256SecretFile)
+		}
+	}
+	if opts.RS256PublicKeyFile != "" {
+		b, err := ioutil.ReadFile(opts.RS256PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("auth: %v", err)
+		}
+		if a.rsaKey, err = jwt.ParseRSAPublicKeyFromPEM(b); err != nil {
+			return nil, fmt.Errorf("auth: %s: %v", opts.RS256PublicKeyFile, err)
+		}
+	}
+	if opts.JWKSFile != "" {
+		keys, err := loadJWKS(opts.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.jwks = keys
+	}
+	if opts.APIKeysFile != "" {
+		keys, err := loadAPIKeys(opts.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		a.apiKeys = keys
+	}
+	return a, nil
+}
+
+// Middleware authenticates requests and stores their principal in the
+// request context. Requests with invalid credentials are rejected with 401
+// Unauthorized, as are anonymous requests if so configured.
+//
+// WebSocket connections without credentials are let through anonymously, as
+// browsers cannot set their headers. Their credentials are checked when the
+// connection is initialized instead, see HandlerOptions.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, authorization := r.Header.Get(APIKeyHeader), r.Header.Get("Authorization")
+		if key == "" && authorization == "" && isWebSocket(r) {
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), Anonymous)))
+			return
+		}
+		p, err := a.authenticate(key, authorization)
+		if err != nil {
+			writeUnauthorized(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), p)))
+	})
+}
+
+// Authenticate returns the principal of the request.
+func (a *Authenticator) Authenticate(r *http.Request) (*Principal, error) {
+	return a.authenticate(r.Header.Get(APIKeyHeader), r.Header.Get("Authorization"))
+}
+
+// authenticate returns the principal identified by an API key or an
+// Authorization header value.
+func (a *Authenticator) authenticate(key, authorization string) (*Principal, error) {
+	if key != "" {
+		return a.authenticateAPIKey(key)
+	}
+	if authorization != "" {
+		const prefix = "bearer "
+		if len(authorization) <= len(prefix) || strings.ToLower(authorization[:len(prefix)]) != prefix {
+			return nil, fmt.Errorf("%w: unsupported authorization scheme", ErrUnauthenticated)
+		}
+		return a.AuthenticateToken(authorization[len(prefix):])
+	}
+	if a.rejectAnonymous {
+		return nil, fmt.Errorf("%w: credentials required", ErrUnauthenticated)
+	}
+	return Anonymous, nil
+}
+
+func (a *Authenticator) authenticateAPIKey(key string) (*Principal, error) {
+	hash := sha256.Sum256([]byte(key))
+	var found *Principal
+	// compare with all keys to not leak which one matched through timing
+	for _, k := range a.apiKeys {
+		if subtle.ConstantTimeCompare(hash[:], k.hash[:]) == 1 {
+			found = k.principal
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: invalid API key", ErrUnauthenticated)
+	}
+	return found, nil
+}
+
+// HandlerOptions returns the options of the GraphQL handler authenticating
+// WebSocket connections with the credentials of their connection_init
+// message, which uses the header names as keys:
+//
+//	{"type": "connection_init", "payload": {"Authorization": "Bearer ..."}}
+func (a *Authenticator) HandlerOptions() []handler.Option {
+	return []handler.Option{handler.WebsocketInitFunc(a.websocketInit)}
+}
+
+func (a *Authenticator) websocketInit(ctx context.Context, payload handler.InitPayload) (context.Context, error) {
+	key, authorization := payload.GetString(APIKeyHeader), payload.Authorization()
+	if key == "" && authorization == "" {
+		// keep the principal authenticated by the headers, if any
+		if a.rejectAnonymous && ForContext(ctx).IsAnonymous() {
+			return nil, fmt.Errorf("%w: credentials required", ErrUnauthenticated)
+		}
+		return ctx, nil
+	}
+	p, err := a.authenticate(key, authorization)
+	if err != nil {
+		return nil, err
+	}
+	return WithPrincipal(ctx, p), nil
+}
+
+func isWebSocket(r *http.Request) bool {
+	return strings.Contains(strings.ToLower(r.Header.Get("Upgrade")), "websocket")
+}
+
+// claims are the JWT claims understood by the application.
+type claims struct {
+	jwt.RegisteredClaims
+	Roles   []Role `json:"roles"`
+	AgentID *int64 `json:"agent_id,omitempty"`
+}
+
+// AuthenticateToken verifies a JWT and returns its principal. Tokens must
+// expire, so that a leaked one is not valid forever.
+func (a *Authenticator) AuthenticateToken(token string) (*Principal, error) {
+	var c claims
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"HS256", "RS256"}))
+	if _, err := parser.ParseWithClaims(token, &c, a.key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: token has no subject", ErrUnauthenticated)
+	}
+	if c.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: token has no expiry", ErrUnauthenticated)
+	}
+	if a.issuer != "" && !c.VerifyIssuer(a.issuer, true) {
+		return nil, fmt.Errorf("%w: invalid issuer", ErrUnauthenticated)
+	}
+	if a.audience != "" && !c.VerifyAudience(a.audience, true) {
+		return nil, fmt.Errorf("%w: invalid audience", ErrUnauthenticated)
+	}
+	return &Principal{Subject: c.Subject, Roles: c.Roles, AgentID: c.AgentID}, nil
+}
+
+// key returns the verification key of a token.
+func (a *Authenticator) key(t *jwt.Token) (interface{}, error) {
+	if kid, ok := t.Header["kid"].(string); ok && a.jwks != nil {
+		key, ok := a.jwks[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+		if _, isHMAC := key.([]byte); isHMAC != (t.Method == jwt.SigningMethodHS256) {
+			return nil, fmt.Errorf("key %q cannot verify %s", kid, t.Method.Alg())
+		}
+		return key, nil
+	}
+	switch t.Method {
+	case jwt.SigningMethodHS256:
+		if a.hmacSecret != nil {
+			return a.hmacSecret, nil
+		}
+	case jwt.SigningMethodRS256:
+		if a.rsaKey != nil {
+			return a.rsaKey, nil
+		}
+	}
+	return nil, fmt.Errorf("no key for %s", t.Method.Alg())
+}
+
+func writeUnauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", `Bearer realm="gqlgen-sqlc-example"`)
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]interface{}{{
+			"message":    err.Error(),
+			"extensions": map[string]string{"code": "UNAUTHENTICATED"},
+		}},
+		"data": nil,
+	})
+}
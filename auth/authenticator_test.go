@@ -0,0 +1,350 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/handler"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	secret   = "hs256-secret"
+	issuer   = "https://issuer.example.com"
+	audience = "gqlgen-sqlc-example"
+)
+
+var (
+	rsaOnce           sync.Once
+	rsaKey, otherKey  *rsa.PrivateKey
+	rsaPublicKeyBytes []byte
+)
+
+// rsaKeys returns the RSA key of the authenticators of the tests, another
+// one and the PEM encoding of the public key of the first one.
+func rsaKeys(t *testing.T) (*rsa.PrivateKey, *rsa.PrivateKey, []byte) {
+	t.Helper()
+	rsaOnce.Do(func() {
+		var err error
+		if rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+		if otherKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rsaPublicKeyBytes = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	})
+	if rsaKey == nil {
+		t.Fatal("no RSA key")
+	}
+	return rsaKey, otherKey, rsaPublicKeyBytes
+}
+
+// writeFile writes content to a file of the temporary directory of t and
+// returns its path.
+func writeFile(t *testing.T, name string, content []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newAuthenticator returns an Authenticator with the HS256 secret, the RS256
+// public key and the API keys of the tests, requiring the issuer and the
+// audience.
+func newAuthenticator(t *testing.T, rejectAnonymous bool) *Authenticator {
+	t.Helper()
+	_, _, publicKey := rsaKeys(t)
+	a, err := New(Options{
+		HS256SecretFile:    writeFile(t, "secret", []byte(secret+"\n")),
+		RS256PublicKeyFile: writeFile(t, "public.pem", publicKey),
+		APIKeysFile:        writeFile(t, "keys.json", []byte(`[{"key": "editor-key", "subject": "ci", "roles": ["EDITOR"], "agent_id": 1}]`)),
+		Issuer:             issuer,
+		Audience:           audience,
+		RejectAnonymous:    rejectAnonymous,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// newJWKSAuthenticator returns an Authenticator with a JWKS holding the RSA
+// key of the tests as "rsa" and the HS256 secret as "hmac".
+func newJWKSAuthenticator(t *testing.T) *Authenticator {
+	t.Helper()
+	key, _, _ := rsaKeys(t)
+	enc := base64.RawURLEncoding.EncodeToString
+	jwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "n": enc(key.N.Bytes()), "e": enc(big.NewInt(int64(key.E)).Bytes())},
+		{"kty": "oct", "kid": "hmac", "k": enc([]byte(secret))},
+		// keys for other uses are ignored
+		{"kty": "oct", "kid": "enc", "use": "enc", "k": enc([]byte(secret))},
+	}})
+	a, err := New(Options{JWKSFile: writeFile(t, "jwks.json", jwks)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// token returns a token signed by method with key, with the kid header if
+// not empty. The claims are valid for the authenticators of the tests
+// after being changed by modify, if not nil.
+func token(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, modify func(*claims)) string {
+	t.Helper()
+	c := claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user",
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles: []Role{RoleEditor},
+	}
+	if modify != nil {
+		modify(&c)
+	}
+	tok := jwt.NewWithClaims(method, c)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// header returns the header holding the given name and value pairs.
+func header(pairs ...string) http.Header {
+	h := make(http.Header)
+	for i := 0; i < len(pairs); i += 2 {
+		h.Set(pairs[i], pairs[i+1])
+	}
+	return h
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	key, other, publicKey := rsaKeys(t)
+	static := newAuthenticator(t, false)
+	rsaOnly, err := New(Options{RS256PublicKeyFile: writeFile(t, "public.pem", publicKey)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks := newJWKSAuthenticator(t)
+	hs256 := jwt.SigningMethodHS256
+	rs256 := jwt.SigningMethodRS256
+
+	tests := []struct {
+		name  string
+		a     *Authenticator
+		token string
+		ok    bool
+	}{
+		{"HS256", static, token(t, hs256, []byte(secret), "", nil), true},
+		{"RS256", static, token(t, rs256, key, "", nil), true},
+		{"HS256 wrong secret", static, token(t, hs256, []byte("other"), "", nil), false},
+		{"RS256 wrong key", static, token(t, rs256, other, "", nil), false},
+		{"RS384", static, token(t, jwt.SigningMethodRS384, key, "", nil), false},
+		{"none", static, token(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", nil), false},
+		// the public key must not be usable as an HS256 secret
+		{"HS256 signed with the public key", rsaOnly, token(t, hs256, publicKey, "", nil), false},
+		{"HS256 without secret", rsaOnly, token(t, hs256, []byte(secret), "", nil), false},
+		{"expired", static, token(t, hs256, []byte(secret), "", func(c *claims) {
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		}), false},
+		{"no expiry", static, token(t, hs256, []byte(secret), "", func(c *claims) { c.ExpiresAt = nil }), false},
+		{"not yet valid", static, token(t, hs256, []byte(secret), "", func(c *claims) {
+			c.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour))
+		}), false},
+		{"no subject", static, token(t, hs256, []byte(secret), "", func(c *claims) { c.Subject = "" }), false},
+		{"wrong issuer", static, token(t, hs256, []byte(secret), "", func(c *claims) { c.Issuer = "https://other.example.com" }), false},
+		{"no issuer", static, token(t, hs256, []byte(secret), "", func(c *claims) { c.Issuer = "" }), false},
+		{"wrong audience", static, token(t, hs256, []byte(secret), "", func(c *claims) { c.Audience = jwt.ClaimStrings{"other"} }), false},
+		{"one of the audiences", static, token(t, hs256, []byte(secret), "", func(c *claims) {
+			c.Audience = jwt.ClaimStrings{"other", audience}
+		}), true},
+		{"no audience", static, token(t, hs256, []byte(secret), "", func(c *claims) { c.Audience = nil }), false},
+		{"issuer and audience not required", jwks, token(t, rs256, key, "rsa", func(c *claims) {
+			c.Issuer, c.Audience = "", nil
+		}), true},
+
+		{"JWKS RS256", jwks, token(t, rs256, key, "rsa", nil), true},
+		{"JWKS HS256", jwks, token(t, hs256, []byte(secret), "hmac", nil), true},
+		{"JWKS unknown kid", jwks, token(t, rs256, key, "unknown", nil), false},
+		{"JWKS key of another use", jwks, token(t, hs256, []byte(secret), "enc", nil), false},
+		{"JWKS wrong key of kid", jwks, token(t, rs256, other, "rsa", nil), false},
+		{"JWKS HS256 with RSA kid", jwks, token(t, hs256, publicKey, "rsa", nil), false},
+		{"JWKS RS256 with HMAC kid", jwks, token(t, rs256, key, "hmac", nil), false},
+		{"JWKS without kid", jwks, token(t, rs256, key, "", nil), false},
+		{"malformed", static, "not.a.token", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.a.AuthenticateToken(tt.token)
+			if !tt.ok {
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("got %+v, %v, want %v", p, err, ErrUnauthenticated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Subject != "user" || len(p.Roles) != 1 || p.Roles[0] != RoleEditor {
+				t.Errorf("got %+v", p)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	valid := "Bearer " + token(t, jwt.SigningMethodHS256, []byte(secret), "", nil)
+	tests := []struct {
+		name            string
+		rejectAnonymous bool
+		header          http.Header
+		want            string // the subject, empty for anonymous
+		ok              bool
+	}{
+		{"API key", false, header(APIKeyHeader, "editor-key"), "ci", true},
+		{"invalid API key", false, header(APIKeyHeader, "other-key"), "", false},
+		{"API key before token", false, header(APIKeyHeader, "editor-key", "Authorization", valid), "ci", true},
+		{"token", false, header("Authorization", valid), "user", true},
+		{"lowercase scheme", false, header("Authorization", "bearer "+valid[len("Bearer "):]), "user", true},
+		{"invalid token", false, header("Authorization", "Bearer invalid"), "", false},
+		{"basic", false, header("Authorization", "Basic dXNlcjpwYXNz"), "", false},
+		{"anonymous", false, header(), "", true},
+		{"anonymous rejected", true, header(), "", false},
+		{"API key with anonymous rejected", true, header(APIKeyHeader, "editor-key"), "ci", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAuthenticator(t, tt.rejectAnonymous)
+			r := httptest.NewRequest(http.MethodPost, "/query", nil)
+			r.Header = tt.header
+			p, err := a.Authenticate(r)
+			if !tt.ok {
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("got %+v, %v, want %v", p, err, ErrUnauthenticated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Subject != tt.want {
+				t.Errorf("subject = %q, want %q", p.Subject, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	a := newAuthenticator(t, true)
+	h := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(ForContext(r.Context()).Subject))
+	}))
+	tests := []struct {
+		name       string
+		header     http.Header
+		wantStatus int
+		wantBody   string
+	}{
+		{"API key", header(APIKeyHeader, "editor-key"), http.StatusOK, "ci"},
+		{"anonymous rejected", header(), http.StatusUnauthorized, ""},
+		{"invalid API key", header(APIKeyHeader, "other-key"), http.StatusUnauthorized, ""},
+		// browsers cannot set the headers of WebSocket connections, they
+		// are authenticated by websocketInit
+		{"anonymous WebSocket", header("Upgrade", "websocket"), http.StatusOK, ""},
+		{"invalid WebSocket", header("Upgrade", "websocket", APIKeyHeader, "other-key"), http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/query", nil)
+			r.Header = tt.header
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Code == http.StatusUnauthorized {
+				var res struct {
+					Errors []struct{ Extensions struct{ Code string } }
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || len(res.Errors) != 1 || res.Errors[0].Extensions.Code != "UNAUTHENTICATED" {
+					t.Errorf("body = %s, want an UNAUTHENTICATED error", w.Body)
+				}
+				if w.Header().Get("WWW-Authenticate") == "" {
+					t.Error("no WWW-Authenticate header")
+				}
+				return
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("subject = %q, want %q", w.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestWebsocketInit(t *testing.T) {
+	valid := "Bearer " + token(t, jwt.SigningMethodHS256, []byte(secret), "", nil)
+	byHeaders := &Principal{Subject: "headers"}
+	tests := []struct {
+		name            string
+		rejectAnonymous bool
+		principal       *Principal // authenticated by the headers
+		payload         handler.InitPayload
+		want            string
+		ok              bool
+	}{
+		{"token", true, Anonymous, handler.InitPayload{"Authorization": valid}, "user", true},
+		{"lowercase key", true, Anonymous, handler.InitPayload{"authorization": valid}, "user", true},
+		{"API key", true, Anonymous, handler.InitPayload{APIKeyHeader: "editor-key"}, "ci", true},
+		{"invalid token", false, Anonymous, handler.InitPayload{"Authorization": "Bearer invalid"}, "", false},
+		{"invalid API key", false, Anonymous, handler.InitPayload{APIKeyHeader: "other-key"}, "", false},
+		{"payload over headers", false, byHeaders, handler.InitPayload{APIKeyHeader: "editor-key"}, "ci", true},
+		{"headers", true, byHeaders, nil, "headers", true},
+		{"anonymous", false, Anonymous, nil, "", true},
+		{"anonymous rejected", true, Anonymous, handler.InitPayload{}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAuthenticator(t, tt.rejectAnonymous)
+			ctx, err := a.websocketInit(WithPrincipal(context.Background(), tt.principal), tt.payload)
+			if !tt.ok {
+				if !errors.Is(err, ErrUnauthenticated) {
+					t.Fatalf("got %v, want %v", err, ErrUnauthenticated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ForContext(ctx).Subject; got != tt.want {
+				t.Errorf("subject = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+)
+
+// jwk is a JSON Web Key, only RSA and symmetric keys are supported.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// loadJWKS reads a JSON Web Key Set, returning the keys by their kid. RSA
+// keys are returned as *rsa.PublicKey and symmetric keys as []byte.
+func loadJWKS(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: %v", err)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("auth: %s: %v", path, err)
+	}
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if k.Kid == "" {
+			return nil, fmt.Errorf("auth: %s: key without kid", path)
+		}
+		key, err := k.key()
+		if err != nil {
+			return nil, fmt.Errorf("auth: %s: key %q: %v", path, k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k jwk) key() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %v", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %v", err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("exponent too large")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil || len(secret) == 0 {
+			return nil, fmt.Errorf("invalid secret")
+		}
+		return secret, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// loadAPIKeys reads the API keys file, a JSON array of objects like
+//
+//	{"key": "...", "subject": "ci", "roles": ["EDITOR"], "agent_id": 1}
+func loadAPIKeys(path string) ([]apiKey, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: %v", err)
+	}
+	var entries []struct {
+		Key     string `json:"key"`
+		Subject string `json:"subject"`
+		Roles   []Role `json:"roles"`
+		AgentID *int64 `json:"agent_id"`
+	}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("auth: %s: %v", path, err)
+	}
+	keys := make([]apiKey, len(entries))
+	for i, e := range entries {
+		if e.Key == "" || e.Subject == "" {
+			return nil, fmt.Errorf("auth: %s: entry %d: key and subject are required", path, i)
+		}
+		keys[i] = apiKey{
+			hash:      sha256.Sum256([]byte(e.Key)),
+			principal: &Principal{Subject: e.Subject, Roles: e.Roles, AgentID: e.AgentID},
+		}
+	}
+	return keys, nil
+}
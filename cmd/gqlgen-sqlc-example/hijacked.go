@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// hijackedConns tracks the connections taken over by the handlers, i.e. the
+// WebSocket connections of the subscriptions, which http.Server.Shutdown
+// neither closes nor waits for.
+type hijackedConns struct {
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	// wg counts the handlers of the hijacked connections still running
+	wg sync.WaitGroup
+}
+
+func newHijackedConns() *hijackedConns {
+	return &hijackedConns{conns: make(map[net.Conn]struct{})}
+}
+
+// Middleware tracks the connections hijacked by next until it returns.
+func (h *hijackedConns) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hw := &hijackWriter{ResponseWriter: w, conns: h}
+		next.ServeHTTP(hw, r)
+		if hw.conn != nil {
+			h.mu.Lock()
+			delete(h.conns, hw.conn)
+			h.mu.Unlock()
+			h.wg.Done()
+		}
+	})
+}
+
+// Close closes the hijacked connections and waits until their handlers
+// return or ctx is done. The connections hijacked afterwards are refused.
+func (h *hijackedConns) Close(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	for conn := range h.conns {
+		conn.Close()
+	}
+	h.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type hijackWriter struct {
+	http.ResponseWriter
+	conns *hijackedConns
+	conn  net.Conn
+}
+
+func (w *hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	h := w.conns
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, nil, errors.New("server is shutting down")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	h.conns[conn] = struct{}{}
+	h.wg.Add(1)
+	w.conn = conn
+	return conn, rw, nil
+}
@@ -1,38 +1,350 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/99designs/gqlgen/handler"
+	"github.com/fwojciec/gqlgen-sqlc-example/auth"        // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/config"      // update the username
 	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
 	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/health"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/logging"     // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/memory"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/metrics"     // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/outbox"      // update the username
 	"github.com/fwojciec/gqlgen-sqlc-example/pg"          // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/purge"       // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/tracing"     // update the username
 )
 
 func main() {
-	// initialize the db
-	db, err := pg.Open("dbname=gqlgen_sqlc_example_db sslmode=disable")
+	cfg, args, err := config.Load(os.Args[1:], os.Getenv)
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// run the migrate subcommand
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := runMigrate(cfg.DSN, args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// run the import-queries subcommand
+	if len(args) > 0 && args[0] == "import-queries" {
+		if err := runImportQueries(cfg.DSN, args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := serve(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// serve runs the server until it receives SIGINT or SIGTERM and then shuts
+// it down gracefully.
+func serve(cfg *config.Config) error {
+	// initialize the instrumentation
+	level, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	logger := logging.New(os.Stderr, level)
+	checker := health.NewChecker(cfg.HealthCheckTimeout)
+	m := metrics.New()
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter: cfg.TraceExporter,
+		Endpoint: cfg.OTLPEndpoint,
+		Insecure: cfg.OTLPInsecure,
+	})
 	if err != nil {
-		panic(err)
+		return err
 	}
-	defer db.Close()
+	defer func() {
+		// flush the spans of the drained requests
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownTracing(ctx)
+	}()
 
 	// initialize the repository
-	repo := pg.NewRepository(db)
+	var repo pg.Repository
+	var queryStore gqlgen.PersistedQueryStore
+	var broker gqlgen.Broker = memory.NewBroker()
+	if cfg.Memory {
+		repo = memory.NewRepository()
+	} else {
+		// initialize the db
+		db, err := pg.Open(cfg.DSN)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		migrator, err := pg.NewMigrator(db)
+		if err != nil {
+			return err
+		}
+		checker.Add("database", db.PingContext)
+		checker.Add("migrations", migrator.Check)
+		checker.Add("pool", pg.PoolCheck(db))
+		m.RegisterDB(db)
+		middlewares := []pg.DBTXMiddleware{m.DBTX, tracing.DBTX}
+		if cfg.SlowQueryThreshold > 0 {
+			middlewares = append(middlewares, logger.SlowQueries(cfg.SlowQueryThreshold))
+		}
+		repo = pg.NewRepository(db, middlewares...)
+		if cfg.PersistedQueryStore == "postgres" {
+			queryStore = pg.NewPersistedQueryStore(db, cfg.PersistedQueryCacheSize, middlewares...)
+		}
+		if cfg.SubscriptionBroker == "postgres" {
+			pgBroker := pg.NewBroker(db, cfg.DSN, middlewares...)
+			defer pgBroker.Close()
+			broker = pgBroker
+		}
+	}
+
+	// the metrics record the names of the configured operations and of the
+	// first other ones, and those of the persisted query manifest below
+	m.KnownOperations(cfg.MetricsOperations)
+	m.MaxOperations(cfg.MetricsMaxOperations)
+
+	// initialize the persisted queries
+	if queryStore == nil {
+		queryStore = memory.NewPersistedQueryStore(cfg.PersistedQueryCacheSize)
+	}
+	if cfg.PersistedQueryManifest != "" {
+		queries, err := importManifest(context.Background(), queryStore, cfg.PersistedQueryManifest)
+		if err != nil {
+			return err
+		}
+		logger.Info("imported persisted queries", "manifest", cfg.PersistedQueryManifest, "queries", len(queries))
+		m.KnownOperations(gqlgen.OperationNames(queries))
+	}
+
+	// initialize the authentication
+	authenticator, err := auth.New(auth.Options{
+		HS256SecretFile:    cfg.AuthHS256SecretFile,
+		RS256PublicKeyFile: cfg.AuthRS256PublicKeyFile,
+		JWKSFile:           cfg.AuthJWKSFile,
+		APIKeysFile:        cfg.AuthAPIKeysFile,
+		Issuer:             cfg.AuthIssuer,
+		Audience:           cfg.AuthAudience,
+		RejectAnonymous:    cfg.AuthAnonymous == "reject",
+	})
+	if err != nil {
+		return err
+	}
+
+	// initialize the outbox dispatcher
+	sinks, err := outboxSinks(cfg)
+	if err != nil {
+		return err
+	}
+	for _, sink := range sinks {
+		if c, ok := sink.(io.Closer); ok {
+			defer c.Close()
+		}
+	}
+	dispatcher := &outbox.Dispatcher{
+		Store:        repo,
+		Sinks:        sinks,
+		Logger:       logger,
+		PollInterval: cfg.OutboxPollInterval,
+		BatchSize:    cfg.OutboxBatchSize,
+		MaxBackoff:   cfg.OutboxMaxBackoff,
+	}
+
+	// initialize the purge of the deleted entities and the delivered events
+	purgeJob := &purge.Job{
+		Store:           repo,
+		Logger:          logger,
+		Retention:       cfg.PurgeRetention,
+		OutboxRetention: cfg.OutboxRetention,
+		Interval:        cfg.PurgeInterval,
+	}
 
 	// initialize the dataloaders
 	dl := dataloaders.NewRetriever() // <- here we initialize the dataloader.Retriever
 
 	// configure the server
 	mux := http.NewServeMux()
-	mux.Handle("/", gqlgen.NewPlaygroundHandler("/query"))
-	dlMiddleware := dataloaders.Middleware(repo)     // <- here we initialize the middleware
-	queryHandler := gqlgen.NewHandler(repo, dl)      // <- use dataloader.Retriever here
-	mux.Handle("/query", dlMiddleware(queryHandler)) // <- use dataloader.Middleware here
+	if cfg.Playground {
+		mux.Handle("/", gqlgen.NewPlaygroundHandler("/query"))
+	}
+	mux.Handle("/healthz", checker.Liveness())
+	mux.Handle("/readyz", checker.Readiness())
+	mux.Handle("/metrics", m.Handler())
+	dlConfig := dataloaders.Config{
+		MaxBatch: cfg.DataLoaderMaxBatch,
+		Wait:     cfg.DataLoaderWait,
+		Loaders:  make(map[string]dataloaders.LoaderConfig, len(cfg.DataLoaderOverrides)),
+		Adaptive: cfg.DataLoaderAdaptive,
+		Observer: dataloaders.Observers(m, tracing.BatchObserver{}, logging.BatchCounter{}),
+	}
+	for name, o := range cfg.DataLoaderOverrides {
+		dlConfig.Loaders[name] = dataloaders.LoaderConfig{MaxBatch: o.MaxBatch, Wait: o.Wait}
+	}
+	if err := dlConfig.Validate(); err != nil {
+		return err
+	}
+	dlMiddleware := dataloaders.Middleware(repo, dlConfig) // <- here we initialize the middleware
+	var handlerOptions []handler.Option
+	handlerOptions = append(handlerOptions, dlConfig.HandlerOptions(gqlgen.Schema())...)
+	handlerOptions = append(handlerOptions, logger.HandlerOptions()...)
+	handlerOptions = append(handlerOptions, m.HandlerOptions()...)
+	handlerOptions = append(handlerOptions, tracing.HandlerOptions()...)
+	handlerOptions = append(handlerOptions, authenticator.HandlerOptions()...)
+	if cfg.PersistedQueries != "off" {
+		persisted := gqlgen.PersistedQueries{
+			Store:     queryStore,
+			Allowlist: cfg.PersistedQueries == "allowlist",
+			Logger:    logger,
+		}
+		handlerOptions = append(handlerOptions, persisted.HandlerOptions()...)
+	}
+	limits := gqlgen.Limits{
+		MaxDepth:      cfg.MaxQueryDepth,
+		MaxComplexity: cfg.MaxQueryComplexity,
+	}
+	queryHandler := gqlgen.NewHandler(repo, dl, broker, limits, handlerOptions...) // <- use dataloader.Retriever here
+	hijacked := newHijackedConns()
+	mux.Handle("/query", hijacked.Middleware(logging.RequestID(tracing.Middleware(authenticator.Middleware(
+		dlMiddleware(queryHandler), // <- use dataloader.Middleware here
+	)))))
+	// the subscriptions run until the base context of their requests is
+	// canceled
+	baseCtx, stopSubscriptions := context.WithCancel(context.Background())
+	defer stopSubscriptions()
+	srv := &http.Server{
+		Addr:         cfg.ListenAddr,
+		Handler:      mux,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+		BaseContext:  func(net.Listener) context.Context { return baseCtx },
+	}
+
+	// run the outbox dispatcher
+	dispatchCtx, stopDispatcher := context.WithCancel(context.Background())
+	dispatcherDone := make(chan struct{})
+	go func() {
+		defer close(dispatcherDone)
+		if len(sinks) > 0 {
+			dispatcher.Run(dispatchCtx)
+		}
+	}()
+	defer func() {
+		stopDispatcher()
+		<-dispatcherDone
+	}()
+
+	// run the purge job
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	purgeDone := make(chan struct{})
+	go func() {
+		defer close(purgeDone)
+		if cfg.PurgeRetention > 0 || cfg.OutboxRetention > 0 {
+			purgeJob.Run(purgeCtx)
+		}
+	}()
+	defer func() {
+		stopPurge()
+		<-purgeDone
+	}()
 
 	// run the server
-	port := ":8080"
-	fmt.Fprintf(os.Stdout, "🚀 Server ready at http://localhost%s\n", port)
-	fmt.Fprintln(os.Stderr, http.ListenAndServe(port, mux))
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+	fmt.Fprintf(os.Stdout, "🚀 Server ready at http://%s\n", displayAddr(cfg.ListenAddr))
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-errc:
+		return err
+	case sig := <-sigc:
+		logger.Info("shutting down", "signal", sig.String())
+	}
+	// a second signal terminates the process immediately
+	signal.Stop(sigc)
+
+	// report not ready, so that load balancers stop routing new requests here
+	checker.Shutdown()
+	time.Sleep(cfg.ShutdownDelay)
+
+	// let in-flight operations, including their transactions, finish
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DrainTimeout)
+	defer cancel()
+	err = srv.Shutdown(ctx)
+	// end the subscriptions and close their WebSocket connections, which
+	// Shutdown does not wait for, before the database is closed
+	stopSubscriptions()
+	if herr := hijacked.Close(ctx); err == nil {
+		err = herr
+	}
+	if err != nil {
+		srv.Close()
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	logger.Info("server stopped")
+	return nil
+}
+
+// outboxSinks returns the configured sinks of the outbox events.
+func outboxSinks(cfg *config.Config) ([]outbox.Sink, error) {
+	var sinks []outbox.Sink
+	if cfg.OutboxWebhookURL != "" {
+		sinks = append(sinks, &outbox.WebhookSink{
+			URL: cfg.OutboxWebhookURL,
+			Client: &http.Client{
+				Transport: tracing.Transport(nil),
+				Timeout:   cfg.OutboxWebhookTimeout,
+			},
+		})
+	}
+	if cfg.OutboxFile != "" {
+		sink, err := outbox.OpenFileSink(cfg.OutboxFile)
+		if err != nil {
+			return nil, fmt.Errorf("outbox: %w", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.OutboxStdout {
+		sinks = append(sinks, outbox.NewWriterSink(os.Stdout))
+	}
+	return sinks, nil
+}
+
+// displayAddr returns addr with localhost as the host if none is set.
+func displayAddr(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
 }
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+const migrateUsage = "usage: gqlgen-sqlc-example migrate up|down|status|to N"
+
+// runMigrate executes the migrate subcommand.
+func runMigrate(dataSourceName string, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	db, err := pg.Open(dataSourceName)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	m, err := pg.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		err = m.Up(ctx)
+	case args[0] == "down" && len(args) == 1:
+		err = m.Down(ctx)
+	case args[0] == "to" && len(args) == 2:
+		version, perr := strconv.ParseInt(args[1], 10, 64)
+		if perr != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		err = m.To(ctx, version)
+	case args[0] == "status" && len(args) == 1:
+		return printMigrationStatus(ctx, m)
+	default:
+		return errors.New(migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "database is at version %d\n", version)
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, m *pg.Migrator) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range status {
+		appliedAt := "pending"
+		if s.Applied {
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"     // update the username
+)
+
+const importQueriesUsage = "usage: gqlgen-sqlc-example import-queries MANIFEST"
+
+// runImportQueries executes the import-queries subcommand, which stores the
+// queries of a manifest in the persisted_queries table as imported queries,
+// the only ones executed in allowlist mode.
+func runImportQueries(dataSourceName string, args []string) error {
+	if len(args) != 1 {
+		return errors.New(importQueriesUsage)
+	}
+	db, err := pg.Open(dataSourceName)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	queries, err := importManifest(context.Background(), pg.NewPersistedQueryStore(db, 0), args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "imported %d persisted queries\n", len(queries))
+	return nil
+}
+
+func importManifest(ctx context.Context, store gqlgen.PersistedQueryStore, path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return gqlgen.ImportManifest(ctx, store, f)
+}
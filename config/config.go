@@ -0,0 +1,697 @@
+// Package config loads the server configuration.
+//
+// Settings are read from, in order of increasing precedence:
+//
+//  1. the defaults returned by Default,
+//  2. a YAML (.yaml, .yml) or TOML (.toml) file given by the -config flag or
+//     the GQLGEN_SQLC_CONFIG environment variable,
+//  3. environment variables named GQLGEN_SQLC_<SETTING>, e.g.
+//     GQLGEN_SQLC_LISTEN_ADDR,
+//  4. command line flags, e.g. -listen-addr.
+//
+// The config file uses the flag names as keys:
+//
+//	listen-addr: ":8080"
+//	dsn: "dbname=gqlgen_sqlc_example_db sslmode=disable"
+//	dataloader-wait: 2ms
+//	dataloader-overrides: "BooksByAuthorID.max-batch=50,HistoryByBookID.wait=10ms"
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix is the prefix of the environment variables read by Load.
+const EnvPrefix = "GQLGEN_SQLC_"
+
+// Config is the configuration of the server.
+type Config struct {
+	// ListenAddr is the TCP address the server listens on.
+	ListenAddr string
+
+	// http server timeouts, see net/http.Server
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	// ShutdownDelay is how long the server keeps serving while reporting
+	// that it is not ready, before it stops accepting connections.
+	ShutdownDelay time.Duration
+	// DrainTimeout is how long in-flight requests may take to finish during
+	// shutdown.
+	DrainTimeout time.Duration
+	// HealthCheckTimeout is how long the readiness checks may take.
+	HealthCheckTimeout time.Duration
+
+	// Memory selects the in-memory repository instead of postgres.
+	Memory bool
+	// DSN is the postgres data source name.
+	DSN string
+
+	// connection pool settings, see database/sql.DB
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	// dataloader batching settings, see dataloaders.Config
+	DataLoaderMaxBatch int
+	DataLoaderWait     time.Duration
+	// DataLoaderOverrides overrides the settings above for individual
+	// loaders, by loader name.
+	DataLoaderOverrides map[string]DataLoaderOverride
+	// DataLoaderAdaptive makes the dataloaders fetch as soon as all the
+	// resolvers wait for them, DataLoaderWait then bounds their wait.
+	DataLoaderAdaptive bool
+
+	// operation limits, see gqlgen.Limits
+	MaxQueryDepth      int
+	MaxQueryComplexity int
+
+	// PersistedQueries is "off", "apq" to let clients register queries by
+	// their hash, or "allowlist" to only execute the registered queries.
+	PersistedQueries string
+	// PersistedQueryStore is "memory" or "postgres".
+	PersistedQueryStore string
+	// PersistedQueryCacheSize is the number of queries registered by clients
+	// in apq mode which are kept, the imported queries are always kept.
+	PersistedQueryCacheSize int
+	// PersistedQueryManifest is a manifest file imported at startup.
+	PersistedQueryManifest string
+
+	// SubscriptionBroker is "memory" to deliver events within the process,
+	// or "postgres" to deliver them to every server sharing the database.
+	SubscriptionBroker string
+
+	// outbox sinks, the outbox dispatcher runs if any of them is set, see
+	// package outbox. The events are recorded regardless and stay pending
+	// until a server sharing the database delivers them.
+	OutboxWebhookURL string
+	OutboxFile       string
+	OutboxStdout     bool
+	// outbox dispatcher settings
+	OutboxPollInterval   time.Duration
+	OutboxBatchSize      int
+	OutboxMaxBackoff     time.Duration
+	OutboxWebhookTimeout time.Duration
+	// OutboxRetention is how long delivered events are kept before they are
+	// purged, 0 keeps them.
+	OutboxRetention time.Duration
+
+	// PurgeRetention is how long deleted entities can be restored before
+	// they are purged, 0 disables the purge.
+	PurgeRetention time.Duration
+	// PurgeInterval is how often the deleted entities and the delivered
+	// events are purged.
+	PurgeInterval time.Duration
+
+	// tracing settings, see package tracing
+	TraceExporter string
+	OTLPEndpoint  string
+	OTLPInsecure  bool
+
+	// authentication settings, see package auth
+	AuthHS256SecretFile    string
+	AuthRS256PublicKeyFile string
+	AuthJWKSFile           string
+	AuthAPIKeysFile        string
+	AuthIssuer             string
+	AuthAudience           string
+	// AuthAnonymous is "allow" to serve anonymous requests without any
+	// roles, or "reject" to require credentials.
+	AuthAnonymous string
+
+	// MetricsOperations are the names of the operations whose metrics are
+	// recorded under their own name, in addition to those of the persisted
+	// query manifest.
+	MetricsOperations []string
+	// MetricsMaxOperations is the number of other named operations whose
+	// metrics are recorded under their own name, the first ones seen, the
+	// others are recorded as "other".
+	MetricsMaxOperations int
+
+	// Playground enables the GraphQL Playground at /.
+	Playground bool
+	// LogLevel is one of debug, info, warn or error.
+	LogLevel string
+	// SlowQueryThreshold is the duration above which SQL statements are
+	// logged, 0 disables the slow query log.
+	SlowQueryThreshold time.Duration
+}
+
+// DataLoaderOverride overrides the batching settings of a dataloader, the
+// nil fields keep the global settings.
+type DataLoaderOverride struct {
+	MaxBatch *int
+	Wait     *time.Duration
+}
+
+// Default returns the default configuration.
+func Default() *Config {
+	return &Config{
+		ListenAddr:              ":8080",
+		ReadTimeout:             10 * time.Second,
+		WriteTimeout:            30 * time.Second,
+		IdleTimeout:             2 * time.Minute,
+		ShutdownDelay:           5 * time.Second,
+		DrainTimeout:            30 * time.Second,
+		HealthCheckTimeout:      2 * time.Second,
+		DSN:                     "dbname=gqlgen_sqlc_example_db sslmode=disable",
+		MaxOpenConns:            25,
+		MaxIdleConns:            25,
+		ConnMaxLifetime:         5 * time.Minute,
+		DataLoaderMaxBatch:      100,
+		DataLoaderWait:          5 * time.Millisecond,
+		MaxQueryDepth:           12,
+		MaxQueryComplexity:      50000,
+		PersistedQueries:        "apq",
+		PersistedQueryStore:     "memory",
+		PersistedQueryCacheSize: 1000,
+		SubscriptionBroker:      "memory",
+		OutboxPollInterval:      time.Second,
+		OutboxBatchSize:         100,
+		OutboxMaxBackoff:        10 * time.Minute,
+		OutboxWebhookTimeout:    10 * time.Second,
+		OutboxRetention:         7 * 24 * time.Hour,
+		PurgeRetention:          30 * 24 * time.Hour,
+		PurgeInterval:           time.Hour,
+		TraceExporter:           "none",
+		OTLPEndpoint:            "localhost:4318",
+		AuthAnonymous:           "allow",
+		MetricsMaxOperations:    100,
+		Playground:              true,
+		LogLevel:                "info",
+		SlowQueryThreshold:      200 * time.Millisecond,
+	}
+}
+
+// setting is a configuration value which can be set from a file, the
+// environment or a flag.
+type setting struct {
+	name   string
+	usage  string
+	isBool bool
+	set    func(c *Config, v string) error
+}
+
+var settings = []setting{
+	{name: "listen-addr", usage: "TCP address to listen on", set: func(c *Config, v string) error {
+		c.ListenAddr = v
+		return nil
+	}},
+	{name: "read-timeout", usage: "maximum duration for reading a request, 0 means no limit", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.ReadTimeout)
+	}},
+	{name: "write-timeout", usage: "maximum duration for writing a response, 0 means no limit", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.WriteTimeout)
+	}},
+	{name: "idle-timeout", usage: "maximum time to wait for the next request on a keep-alive connection", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.IdleTimeout)
+	}},
+	{name: "shutdown-delay", usage: "time to report not ready before shutting down", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.ShutdownDelay)
+	}},
+	{name: "drain-timeout", usage: "time in-flight requests may take to finish during shutdown", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.DrainTimeout)
+	}},
+	{name: "health-check-timeout", usage: "time the readiness checks may take", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.HealthCheckTimeout)
+	}},
+	{name: "memory", usage: "use an in-memory repository instead of postgres", isBool: true, set: func(c *Config, v string) error {
+		return parseBool(v, &c.Memory)
+	}},
+	{name: "dsn", usage: "postgres data source name", set: func(c *Config, v string) error {
+		c.DSN = v
+		return nil
+	}},
+	{name: "db-max-open-conns", usage: "maximum number of open database connections, 0 means unlimited", set: func(c *Config, v string) error {
+		return parseInt(v, &c.MaxOpenConns)
+	}},
+	{name: "db-max-idle-conns", usage: "maximum number of idle database connections", set: func(c *Config, v string) error {
+		return parseInt(v, &c.MaxIdleConns)
+	}},
+	{name: "db-conn-max-lifetime", usage: "maximum time a database connection is reused, 0 means forever", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.ConnMaxLifetime)
+	}},
+	{name: "dataloader-max-batch", usage: "maximum number of keys fetched by a dataloader in one batch, 0 means unlimited", set: func(c *Config, v string) error {
+		return parseInt(v, &c.DataLoaderMaxBatch)
+	}},
+	{name: "dataloader-wait", usage: "time a dataloader waits for more keys before fetching a batch", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.DataLoaderWait)
+	}},
+	{name: "dataloader-overrides", usage: "comma-separated settings of individual dataloaders, e.g. BooksByAuthorID.max-batch=50,BooksByAuthorID.wait=2ms", set: func(c *Config, v string) error {
+		return parseDataLoaderOverrides(v, &c.DataLoaderOverrides)
+	}},
+	{name: "dataloader-adaptive", usage: "fetch the dataloader batches as soon as all resolvers wait for them, waiting at most dataloader-wait", isBool: true, set: func(c *Config, v string) error {
+		return parseBool(v, &c.DataLoaderAdaptive)
+	}},
+	{name: "max-query-depth", usage: "maximum nesting of fields in an operation, 0 means unlimited", set: func(c *Config, v string) error {
+		return parseInt(v, &c.MaxQueryDepth)
+	}},
+	{name: "max-query-complexity", usage: "maximum cost of an operation, lists weighted by first or last, 0 means unlimited", set: func(c *Config, v string) error {
+		return parseInt(v, &c.MaxQueryComplexity)
+	}},
+	{name: "persisted-queries", usage: "persisted queries: off, apq or allowlist", set: func(c *Config, v string) error {
+		c.PersistedQueries = strings.ToLower(v)
+		return nil
+	}},
+	{name: "persisted-query-store", usage: "store of persisted queries: memory or postgres", set: func(c *Config, v string) error {
+		c.PersistedQueryStore = strings.ToLower(v)
+		return nil
+	}},
+	{name: "persisted-query-cache-size", usage: "number of queries registered by clients in apq mode which are kept", set: func(c *Config, v string) error {
+		return parseInt(v, &c.PersistedQueryCacheSize)
+	}},
+	{name: "persisted-query-manifest", usage: "persisted query manifest imported at startup", set: func(c *Config, v string) error {
+		c.PersistedQueryManifest = v
+		return nil
+	}},
+	{name: "subscription-broker", usage: "broker delivering subscription events: memory or postgres", set: func(c *Config, v string) error {
+		c.SubscriptionBroker = strings.ToLower(v)
+		return nil
+	}},
+	{name: "outbox-webhook-url", usage: "URL the change events are POSTed to", set: func(c *Config, v string) error {
+		c.OutboxWebhookURL = v
+		return nil
+	}},
+	{name: "outbox-file", usage: "file the change events are appended to as NDJSON", set: func(c *Config, v string) error {
+		c.OutboxFile = v
+		return nil
+	}},
+	{name: "outbox-stdout", usage: "write the change events to stdout as NDJSON", isBool: true, set: func(c *Config, v string) error {
+		return parseBool(v, &c.OutboxStdout)
+	}},
+	{name: "outbox-poll-interval", usage: "how often the outbox is checked for events to deliver", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.OutboxPollInterval)
+	}},
+	{name: "outbox-batch-size", usage: "maximum number of events delivered at once", set: func(c *Config, v string) error {
+		return parseInt(v, &c.OutboxBatchSize)
+	}},
+	{name: "outbox-max-backoff", usage: "maximum delay between the retries of an event", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.OutboxMaxBackoff)
+	}},
+	{name: "outbox-webhook-timeout", usage: "maximum duration of a webhook request", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.OutboxWebhookTimeout)
+	}},
+	{name: "outbox-retention", usage: "time delivered change events are kept before they are purged, 0 keeps them", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.OutboxRetention)
+	}},
+	{name: "purge-retention", usage: "time deleted entities are kept before they are purged, 0 disables the purge", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.PurgeRetention)
+	}},
+	{name: "purge-interval", usage: "how often the deleted entities and the delivered events are purged", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.PurgeInterval)
+	}},
+	{name: "trace-exporter", usage: "trace exporter: none, stdout or otlp", set: func(c *Config, v string) error {
+		c.TraceExporter = strings.ToLower(v)
+		return nil
+	}},
+	{name: "otlp-endpoint", usage: "host:port of the OTLP/HTTP trace collector", set: func(c *Config, v string) error {
+		c.OTLPEndpoint = v
+		return nil
+	}},
+	{name: "otlp-insecure", usage: "connect to the OTLP trace collector without TLS", isBool: true, set: func(c *Config, v string) error {
+		return parseBool(v, &c.OTLPInsecure)
+	}},
+	{name: "auth-hs256-secret-file", usage: "file containing the secret of HS256 tokens", set: func(c *Config, v string) error {
+		c.AuthHS256SecretFile = v
+		return nil
+	}},
+	{name: "auth-rs256-public-key-file", usage: "PEM file containing the public key of RS256 tokens", set: func(c *Config, v string) error {
+		c.AuthRS256PublicKeyFile = v
+		return nil
+	}},
+	{name: "auth-jwks-file", usage: "JSON Web Key Set file with the token verification keys", set: func(c *Config, v string) error {
+		c.AuthJWKSFile = v
+		return nil
+	}},
+	{name: "auth-api-keys-file", usage: "JSON file with the static API keys", set: func(c *Config, v string) error {
+		c.AuthAPIKeysFile = v
+		return nil
+	}},
+	{name: "auth-issuer", usage: "required iss claim of tokens", set: func(c *Config, v string) error {
+		c.AuthIssuer = v
+		return nil
+	}},
+	{name: "auth-audience", usage: "required aud claim of tokens", set: func(c *Config, v string) error {
+		c.AuthAudience = v
+		return nil
+	}},
+	{name: "auth-anonymous", usage: "allow or reject requests without credentials", set: func(c *Config, v string) error {
+		c.AuthAnonymous = strings.ToLower(v)
+		return nil
+	}},
+	{name: "metrics-operations", usage: "comma-separated names of the operations whose metrics are recorded under their own name", set: func(c *Config, v string) error {
+		c.MetricsOperations = splitList(v)
+		return nil
+	}},
+	{name: "metrics-max-operations", usage: "number of other named operations whose metrics are recorded under their own name, the first ones seen", set: func(c *Config, v string) error {
+		return parseInt(v, &c.MetricsMaxOperations)
+	}},
+	{name: "playground", usage: "serve the GraphQL Playground at /", isBool: true, set: func(c *Config, v string) error {
+		return parseBool(v, &c.Playground)
+	}},
+	{name: "log-level", usage: "log level: debug, info, warn or error", set: func(c *Config, v string) error {
+		c.LogLevel = strings.ToLower(v)
+		return nil
+	}},
+	{name: "slow-query-threshold", usage: "log SQL statements taking longer than this, 0 disables the slow query log", set: func(c *Config, v string) error {
+		return parseDuration(v, &c.SlowQueryThreshold)
+	}},
+}
+
+// Load builds the configuration from the command line arguments (without the
+// program name), the environment and the config file. It returns the
+// arguments remaining after the flags.
+func Load(args []string, getenv func(string) string) (*Config, []string, error) {
+	fs := flag.NewFlagSet("gqlgen-sqlc-example", flag.ContinueOnError)
+	configFile := fs.String("config", getenv(EnvPrefix+"CONFIG"), "path to a YAML or TOML config file")
+	flags := make(map[string]string)
+	for _, s := range settings {
+		fs.Var(&flagValue{name: s.name, isBool: s.isBool, values: flags}, s.name, s.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	c := Default()
+	if *configFile != "" {
+		if err := c.loadFile(*configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, s := range settings {
+		env := EnvPrefix + strings.ToUpper(strings.Replace(s.name, "-", "_", -1))
+		if v := getenv(env); v != "" {
+			if err := s.set(c, v); err != nil {
+				return nil, nil, fmt.Errorf("config: %s: %v", env, err)
+			}
+		}
+	}
+	for _, s := range settings {
+		if v, ok := flags[s.name]; ok {
+			if err := s.set(c, v); err != nil {
+				return nil, nil, fmt.Errorf("config: -%s: %v", s.name, err)
+			}
+		}
+	}
+	if err := c.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return c, fs.Args(), nil
+}
+
+// loadFile applies the settings of a YAML or TOML file.
+func (c *Config) loadFile(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %v", err)
+	}
+	values := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(b, &values)
+	case ".toml":
+		err = toml.Unmarshal(b, &values)
+	default:
+		return fmt.Errorf("config: %s: unsupported file type %q", path, ext)
+	}
+	if err != nil {
+		return fmt.Errorf("config: %s: %v", path, err)
+	}
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := lookup(k)
+		if s == nil {
+			return fmt.Errorf("config: %s: unknown setting %q", path, k)
+		}
+		switch v := values[k].(type) {
+		case string, bool, int, int64, float64:
+			if err := s.set(c, fmt.Sprint(v)); err != nil {
+				return fmt.Errorf("config: %s: %s: %v", path, k, err)
+			}
+		default:
+			return fmt.Errorf("config: %s: %s: must be a string, number or boolean", path, k)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the configuration is usable and reports all problems
+// found.
+func (c *Config) Validate() error {
+	var problems []string
+	if _, _, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		problems = append(problems, fmt.Sprintf("listen-addr %q: %v", c.ListenAddr, err))
+	}
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"read-timeout", c.ReadTimeout},
+		{"write-timeout", c.WriteTimeout},
+		{"idle-timeout", c.IdleTimeout},
+		{"shutdown-delay", c.ShutdownDelay},
+		{"drain-timeout", c.DrainTimeout},
+		{"health-check-timeout", c.HealthCheckTimeout},
+		{"db-conn-max-lifetime", c.ConnMaxLifetime},
+		{"dataloader-wait", c.DataLoaderWait},
+		{"slow-query-threshold", c.SlowQueryThreshold},
+		{"outbox-retention", c.OutboxRetention},
+		{"purge-retention", c.PurgeRetention},
+	} {
+		if d.value < 0 {
+			problems = append(problems, d.name+" must not be negative")
+		}
+	}
+	if !c.Memory && c.DSN == "" {
+		problems = append(problems, "dsn must be set unless memory is enabled")
+	}
+	if c.MaxOpenConns < 0 {
+		problems = append(problems, "db-max-open-conns must not be negative")
+	}
+	if c.MaxIdleConns < 0 {
+		problems = append(problems, "db-max-idle-conns must not be negative")
+	}
+	if c.MaxOpenConns > 0 && c.MaxIdleConns > c.MaxOpenConns {
+		problems = append(problems, "db-max-idle-conns must not exceed db-max-open-conns")
+	}
+	if c.DataLoaderMaxBatch < 0 {
+		problems = append(problems, "dataloader-max-batch must not be negative")
+	}
+	names := make([]string, 0, len(c.DataLoaderOverrides))
+	for name := range c.DataLoaderOverrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		o := c.DataLoaderOverrides[name]
+		if o.MaxBatch != nil && *o.MaxBatch < 0 {
+			problems = append(problems, fmt.Sprintf("dataloader-overrides %s.max-batch must not be negative", name))
+		}
+		if o.Wait != nil && *o.Wait < 0 {
+			problems = append(problems, fmt.Sprintf("dataloader-overrides %s.wait must not be negative", name))
+		}
+	}
+	if c.MaxQueryDepth < 0 {
+		problems = append(problems, "max-query-depth must not be negative")
+	}
+	if c.MaxQueryComplexity < 0 {
+		problems = append(problems, "max-query-complexity must not be negative")
+	}
+	switch c.PersistedQueries {
+	case "off", "apq", "allowlist":
+	default:
+		problems = append(problems, fmt.Sprintf("persisted-queries %q must be one of off, apq or allowlist", c.PersistedQueries))
+	}
+	switch c.PersistedQueryStore {
+	case "memory":
+		if c.PersistedQueries == "allowlist" && c.PersistedQueryManifest == "" {
+			problems = append(problems, "persisted-queries allowlist with the memory store requires persisted-query-manifest")
+		}
+	case "postgres":
+		if c.Memory {
+			problems = append(problems, "persisted-query-store postgres cannot be used with memory")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("persisted-query-store %q must be memory or postgres", c.PersistedQueryStore))
+	}
+	if c.PersistedQueryCacheSize < 1 {
+		problems = append(problems, "persisted-query-cache-size must be positive")
+	}
+	switch c.SubscriptionBroker {
+	case "memory":
+	case "postgres":
+		if c.Memory {
+			problems = append(problems, "subscription-broker postgres cannot be used with memory")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("subscription-broker %q must be memory or postgres", c.SubscriptionBroker))
+	}
+	if c.OutboxWebhookURL != "" {
+		if u, err := url.Parse(c.OutboxWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("outbox-webhook-url %q must be an absolute http or https URL", c.OutboxWebhookURL))
+		}
+	}
+	if c.OutboxPollInterval <= 0 {
+		problems = append(problems, "outbox-poll-interval must be positive")
+	}
+	if c.OutboxBatchSize < 1 {
+		problems = append(problems, "outbox-batch-size must be positive")
+	}
+	if c.OutboxMaxBackoff <= 0 {
+		problems = append(problems, "outbox-max-backoff must be positive")
+	}
+	if c.OutboxWebhookTimeout <= 0 {
+		problems = append(problems, "outbox-webhook-timeout must be positive")
+	}
+	if c.PurgeInterval <= 0 {
+		problems = append(problems, "purge-interval must be positive")
+	}
+	switch c.TraceExporter {
+	case "none", "stdout":
+	case "otlp":
+		if _, _, err := net.SplitHostPort(c.OTLPEndpoint); err != nil {
+			problems = append(problems, fmt.Sprintf("otlp-endpoint %q: %v", c.OTLPEndpoint, err))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("trace-exporter %q must be one of none, stdout or otlp", c.TraceExporter))
+	}
+	if c.AuthAnonymous != "allow" && c.AuthAnonymous != "reject" {
+		problems = append(problems, fmt.Sprintf("auth-anonymous %q must be allow or reject", c.AuthAnonymous))
+	}
+	if c.MetricsMaxOperations < 0 {
+		problems = append(problems, "metrics-max-operations must not be negative")
+	}
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("log-level %q must be one of debug, info, warn or error", c.LogLevel))
+	}
+	if len(problems) > 0 {
+		return errors.New("config: invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func lookup(name string) *setting {
+	for i := range settings {
+		if settings[i].name == name {
+			return &settings[i]
+		}
+	}
+	return nil
+}
+
+// flagValue records the flags given on the command line, so that they can be
+// applied after the file and the environment.
+type flagValue struct {
+	name   string
+	isBool bool
+	values map[string]string
+}
+
+func (f *flagValue) String() string {
+	return ""
+}
+
+func (f *flagValue) Set(v string) error {
+	f.values[f.name] = v
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
+
+func parseBool(v string, dst *bool) error {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", v)
+	}
+	*dst = b
+	return nil
+}
+
+func parseInt(v string, dst *int) error {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", v)
+	}
+	*dst = i
+	return nil
+}
+
+func parseDuration(v string, dst *time.Duration) error {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", v)
+	}
+	*dst = d
+	return nil
+}
+
+// parseDataLoaderOverrides parses a comma-separated list of loader.setting=value
+// pairs, where setting is max-batch or wait.
+func parseDataLoaderOverrides(v string, dst *map[string]DataLoaderOverride) error {
+	overrides := make(map[string]DataLoaderOverride)
+	for _, pair := range strings.Split(v, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		dot := strings.LastIndex(kv[0], ".")
+		if len(kv) != 2 || dot < 1 {
+			return fmt.Errorf("invalid dataloader override %q, want loader.setting=value", pair)
+		}
+		name, setting := kv[0][:dot], kv[0][dot+1:]
+		o := overrides[name]
+		switch setting {
+		case "max-batch":
+			var maxBatch int
+			if err := parseInt(kv[1], &maxBatch); err != nil {
+				return err
+			}
+			o.MaxBatch = &maxBatch
+		case "wait":
+			var wait time.Duration
+			if err := parseDuration(kv[1], &wait); err != nil {
+				return err
+			}
+			o.Wait = &wait
+		default:
+			return fmt.Errorf("invalid dataloader override %q, setting must be max-batch or wait", pair)
+		}
+		overrides[name] = o
+	}
+	*dst = overrides
+	return nil
+}
+
+// splitList parses a comma-separated list, ignoring the empty items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// env returns a getenv function serving vars.
+func env(vars map[string]string) func(string) string {
+	return func(name string) string {
+		return vars[name]
+	}
+}
+
+// writeFile writes content to a file named name in a temporary directory and
+// returns its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefault(t *testing.T) {
+	c, args, err := Load(nil, env(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 0 {
+		t.Errorf("args = %q, want none", args)
+	}
+	if c.ListenAddr != ":8080" || c.MetricsMaxOperations != 100 || c.LogLevel != "info" || !c.Playground {
+		t.Errorf("config %+v, want the defaults", c)
+	}
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Validate() = %v, want the defaults to be valid", err)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+listen-addr: ":1000"
+log-level: debug
+dataloader-wait: 2ms
+metrics-max-operations: 10
+memory: true
+`)
+	vars := map[string]string{
+		EnvPrefix + "CONFIG":                 path,
+		EnvPrefix + "LOG_LEVEL":              "WARN",
+		EnvPrefix + "METRICS_MAX_OPERATIONS": "20",
+	}
+	c, args, err := Load([]string{"-metrics-max-operations", "30", "-playground=false", "extra"}, env(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		// the file overrides the defaults
+		{"listen-addr", c.ListenAddr, ":1000"},
+		{"dataloader-wait", c.DataLoaderWait, 2 * time.Millisecond},
+		{"memory", c.Memory, true},
+		// the environment overrides the file
+		{"log-level", c.LogLevel, "warn"},
+		// the flags override the environment
+		{"metrics-max-operations", c.MetricsMaxOperations, 30},
+		{"playground", c.Playground, false},
+		// the settings set nowhere keep their defaults
+		{"read-timeout", c.ReadTimeout, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if len(args) != 1 || args[0] != "extra" {
+		t.Errorf("args = %q, want extra", args)
+	}
+}
+
+func TestConfigFlag(t *testing.T) {
+	// the -config flag overrides the environment variable
+	file := writeFile(t, "config.toml", `log-level = "error"
+max-query-depth = 3
+dataloader-overrides = "BooksByAuthorID.max-batch=50,BooksByAuthorID.wait=10ms"
+`)
+	vars := map[string]string{EnvPrefix + "CONFIG": "missing.yaml"}
+	c, _, err := Load([]string{"-config", file}, env(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.LogLevel != "error" || c.MaxQueryDepth != 3 {
+		t.Errorf("log-level %s and max-query-depth %d, want error and 3", c.LogLevel, c.MaxQueryDepth)
+	}
+	o, ok := c.DataLoaderOverrides["BooksByAuthorID"]
+	if !ok || o.MaxBatch == nil || *o.MaxBatch != 50 || o.Wait == nil || *o.Wait != 10*time.Millisecond {
+		t.Errorf("dataloader-overrides %+v, want BooksByAuthorID with max-batch 50 and wait 10ms", c.DataLoaderOverrides)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		vars map[string]string
+		file string
+		want string
+	}{
+		{name: "unknown flag", args: []string{"-unknown"}, want: "not defined"},
+		{name: "invalid flag", args: []string{"-max-query-depth", "deep"}, want: `-max-query-depth: invalid integer "deep"`},
+		{name: "invalid environment variable", vars: map[string]string{EnvPrefix + "READ_TIMEOUT": "10"}, want: EnvPrefix + `READ_TIMEOUT: invalid duration "10"`},
+		{name: "invalid boolean", vars: map[string]string{EnvPrefix + "MEMORY": "maybe"}, want: `invalid boolean "maybe"`},
+		{name: "invalid override", args: []string{"-dataloader-overrides", "BooksByAuthorID.size=3"}, want: "setting must be max-batch or wait"},
+		{name: "missing file", args: []string{"-config", "missing.yaml"}, want: "missing.yaml"},
+		{name: "unsupported file", file: "config.json", want: `unsupported file type ".json"`},
+		{name: "unknown setting", file: "config.yaml", want: `unknown setting "listen"`},
+		{name: "invalid file value", file: "config.toml", want: `dataloader-wait: invalid duration "soon"`},
+		{name: "nested file value", file: "nested.yaml", want: "must be a string, number or boolean"},
+		{name: "duplicate key", file: "duplicate.yaml", want: "already set"},
+	}
+	files := map[string]string{
+		"config.json":    `{"listen-addr": ":1000"}`,
+		"config.yaml":    `listen: ":1000"`,
+		"config.toml":    `dataloader-wait = "soon"`,
+		"nested.yaml":    "listen-addr:\n  port: 1000",
+		"duplicate.yaml": "log-level: info\nlog-level: debug",
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = []string{"-config", writeFile(t, tt.file, files[tt.file])}
+			}
+			_, _, err := Load(args, env(tt.vars))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   []string
+	}{
+		{"listen-addr", func(c *Config) { c.ListenAddr = "8080" }, []string{`listen-addr "8080"`}},
+		{"negative durations", func(c *Config) {
+			c.ReadTimeout = -1
+			c.PurgeRetention = -1
+		}, []string{"read-timeout must not be negative", "purge-retention must not be negative"}},
+		{"dsn", func(c *Config) { c.DSN = "" }, []string{"dsn must be set"}},
+		{"dsn with memory", func(c *Config) {
+			c.DSN = ""
+			c.Memory = true
+		}, nil},
+		{"idle conns", func(c *Config) { c.MaxIdleConns = 30 }, []string{"db-max-idle-conns must not exceed db-max-open-conns"}},
+		{"unlimited open conns", func(c *Config) {
+			c.MaxOpenConns = 0
+			c.MaxIdleConns = 30
+		}, nil},
+		{"dataloader override", func(c *Config) {
+			maxBatch := -1
+			c.DataLoaderOverrides = map[string]DataLoaderOverride{"BooksByAuthorID": {MaxBatch: &maxBatch}}
+		}, []string{"dataloader-overrides BooksByAuthorID.max-batch must not be negative"}},
+		{"persisted queries", func(c *Config) { c.PersistedQueries = "on" }, []string{`persisted-queries "on"`}},
+		{"allowlist without manifest", func(c *Config) { c.PersistedQueries = "allowlist" }, []string{"requires persisted-query-manifest"}},
+		{"postgres store with memory", func(c *Config) {
+			c.Memory = true
+			c.PersistedQueryStore = "postgres"
+			c.SubscriptionBroker = "postgres"
+		}, []string{"persisted-query-store postgres cannot be used with memory", "subscription-broker postgres cannot be used with memory"}},
+		{"webhook url", func(c *Config) { c.OutboxWebhookURL = "/events" }, []string{`outbox-webhook-url "/events"`}},
+		{"outbox", func(c *Config) {
+			c.OutboxPollInterval = 0
+			c.OutboxBatchSize = 0
+		}, []string{"outbox-poll-interval must be positive", "outbox-batch-size must be positive"}},
+		{"otlp endpoint", func(c *Config) {
+			c.TraceExporter = "otlp"
+			c.OTLPEndpoint = "collector"
+		}, []string{`otlp-endpoint "collector"`}},
+		{"trace exporter", func(c *Config) { c.TraceExporter = "jaeger" }, []string{`trace-exporter "jaeger"`}},
+		{"auth-anonymous", func(c *Config) { c.AuthAnonymous = "deny" }, []string{`auth-anonymous "deny"`}},
+		{"metrics-max-operations", func(c *Config) { c.MetricsMaxOperations = -1 }, []string{"metrics-max-operations must not be negative"}},
+		{"unlimited metrics operations", func(c *Config) { c.MetricsMaxOperations = 0 }, nil},
+		{"log-level", func(c *Config) { c.LogLevel = "trace" }, []string{`log-level "trace"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			tt.modify(c)
+			err := c.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want %q", tt.want)
+			}
+			// all the problems are reported
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+
+	// the validation errors are returned by Load
+	_, _, err := Load([]string{"-log-level", "trace", "-auth-anonymous", "deny"}, env(nil))
+	if err == nil || !strings.Contains(err.Error(), "log-level") || !strings.Contains(err.Error(), "auth-anonymous") {
+		t.Errorf("Load() = %v, want the invalid log-level and auth-anonymous", err)
+	}
+}
@@ -0,0 +1,353 @@
+package dataloaders
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/handler"
+	"github.com/vektah/gqlparser/ast"
+)
+
+// In adaptive mode the generated loaders hand every key they miss to a
+// collector without waiting, and the collector merges the keys of each
+// loader into one batch which is held until the dispatcher of the request
+// sees every resolver wait for loaders. The Wait of a loader then only bounds
+// how long its batch may be held, e.g. while another resolver runs a slow
+// query.
+//
+// The dispatcher counts the resolvers explicitly:
+//   - announced: the fields which are about to be resolved, i.e. the root
+//     fields of a query and the fields selected on the objects returned by
+//     the resolvers, counted before they start,
+//   - running: the resolvers which have started and not returned,
+//   - loading: the running resolvers which retrieved the loaders, the
+//     resolvers only do so to load and return,
+//   - calls: the keys which are held or being fetched, the generated loaders
+//     hand each missed key over separately.
+// The resolvers all wait once no field is announced, every running resolver
+// is loading and the keys of every loading resolver have been handed over.
+//
+// A resolver loading several keys, e.g. Nodes, may let a batch go before the
+// key of another loading resolver is handed over: its keys are then fetched
+// in the next batch. Fields wrongly announced only make the batches wait for
+// Wait. The events of subscriptions do not announce their fields, their
+// loaders have no dispatcher, see ForSubscription.
+
+// HandlerOptions returns the handler options required by the adaptive mode,
+// which track the resolvers of the operations executed against schema. It
+// returns none otherwise.
+func (c Config) HandlerOptions(schema *ast.Schema) []handler.Option {
+	if !c.Adaptive {
+		return nil
+	}
+	t := tracker{schema: schema}
+	return []handler.Option{
+		handler.RequestMiddleware(t.announceRoot),
+		handler.ResolverMiddleware(t.track),
+	}
+}
+
+type resolverKey struct{}
+
+// resolver is the state of a running resolver.
+type resolver struct {
+	loading bool
+	done    bool
+}
+
+// tracker reports the resolvers to the dispatcher of the request.
+type tracker struct {
+	schema *ast.Schema
+}
+
+// announceRoot announces the root fields of queries. Mutations resolve
+// their root fields one after the other, and subscriptions resolve a single
+// one before the events.
+func (t tracker) announceRoot(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+	d := dispatcherFor(ctx)
+	rctx := graphql.GetRequestContext(ctx)
+	if d == nil || rctx == nil || rctx.Doc == nil {
+		return next(ctx)
+	}
+	if op := rctx.Doc.Operations.ForName(rctx.OperationName); op != nil && op.Operation == ast.Query {
+		d.announce(countFields(graphql.CollectFields(rctx, op.SelectionSet, []string{"Query"})))
+	}
+	return next(ctx)
+}
+
+func (t tracker) track(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
+	d := dispatcherFor(ctx)
+	if d == nil {
+		return next(ctx)
+	}
+	r := &resolver{}
+	d.start()
+	defer func() {
+		// the fields of the result are resolved once the resolver returns
+		var fields int
+		if err == nil {
+			fields = t.children(ctx, res)
+		}
+		d.finish(r, fields)
+	}()
+	return next(context.WithValue(ctx, resolverKey{}, r))
+}
+
+// children returns the number of fields resolved on the objects of res,
+// the result of the field of ctx.
+func (t tracker) children(ctx context.Context, res interface{}) int {
+	fctx := graphql.GetResolverContext(ctx)
+	rctx := graphql.GetRequestContext(ctx)
+	if fctx == nil || rctx == nil || len(fctx.Field.Selections) == 0 || fctx.Field.Definition == nil {
+		return 0
+	}
+	def := t.schema.Types[fctx.Field.Definition.Type.Name()]
+	if def == nil {
+		return 0
+	}
+	var count func(v reflect.Value) int
+	count = func(v reflect.Value) int {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return 0
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			n := 0
+			for i := 0; i < v.Len(); i++ {
+				n += count(v.Index(i))
+			}
+			return n
+		case reflect.Struct:
+			name := def.Name
+			if def.IsAbstractType() {
+				// the Go types of the members have their names
+				name = v.Type().Name()
+			}
+			return countFields(graphql.CollectFields(rctx, fctx.Field.Selections, t.satisfies(name)))
+		}
+		return 0
+	}
+	return count(reflect.ValueOf(res))
+}
+
+// satisfies returns the names of the object type name and of the interfaces
+// and unions it belongs to.
+func (t tracker) satisfies(name string) []string {
+	names := []string{name}
+	for _, def := range t.schema.Implements[name] {
+		names = append(names, def.Name)
+	}
+	return names
+}
+
+// countFields returns the number of fields resolved by the resolvers,
+// __typename is not.
+func countFields(fields []graphql.CollectedField) int {
+	n := 0
+	for _, f := range fields {
+		if f.Name != "__typename" {
+			n++
+		}
+	}
+	return n
+}
+
+func dispatcherFor(ctx context.Context) *dispatcher {
+	if s, ok := ctx.Value(key).(*scope); ok {
+		return s.dispatcher
+	}
+	return nil
+}
+
+// dispatcher dispatches the batches held by the collectors of a request.
+type dispatcher struct {
+	mu        sync.Mutex
+	announced int
+	running   int
+	loading   int
+	calls     int
+	held      map[*collector]bool
+}
+
+func newDispatcher() *dispatcher {
+	return &dispatcher{held: make(map[*collector]bool)}
+}
+
+// announce records that n fields are about to be resolved.
+func (d *dispatcher) announce(n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.announced += n
+}
+
+// start records that a resolver started.
+func (d *dispatcher) start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.running++
+	if d.announced > 0 {
+		d.announced--
+	}
+}
+
+// load records that the resolver of ctx retrieved the loaders.
+func (d *dispatcher) load(ctx context.Context) {
+	r, ok := ctx.Value(resolverKey{}).(*resolver)
+	if !ok {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if r.loading || r.done {
+		return
+	}
+	r.loading = true
+	d.loading++
+	d.check()
+}
+
+// finish records that r returned, announcing the fields of its result.
+func (d *dispatcher) finish(r *resolver, fields int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	r.done = true
+	d.running--
+	if r.loading {
+		d.loading--
+	}
+	d.announced += fields
+	d.check()
+}
+
+// hold records that c holds a batch.
+func (d *dispatcher) hold(c *collector) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.held[c] = true
+}
+
+// release records that c does not hold a batch anymore.
+func (d *dispatcher) release(c *collector) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.held, c)
+}
+
+// call records that n keys were handed over, or that -n keys were fetched
+// when n is negative.
+func (d *dispatcher) call(n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls += n
+	d.check()
+}
+
+// check dispatches the held batches if the resolvers all wait for them. It
+// is called with d.mu held.
+func (d *dispatcher) check() {
+	if len(d.held) == 0 || d.announced > 0 || d.running != d.loading || d.calls < d.loading {
+		return
+	}
+	for c := range d.held {
+		delete(d.held, c)
+		go c.dispatch(nil)
+	}
+}
+
+// collector merges the fetches of a loader into batches.
+type collector struct {
+	d        *dispatcher
+	fetch    reflect.Value
+	maxBatch int
+	wait     time.Duration
+
+	mu    sync.Mutex
+	batch *mergedBatch
+}
+
+// mergedBatch holds the merged keys of several fetches and, once dispatched,
+// their results.
+type mergedBatch struct {
+	keys  reflect.Value
+	timer *time.Timer
+	done  chan struct{}
+
+	values reflect.Value
+	errs   []error
+}
+
+// merge returns a fetch function of the same type as fetch, a function
+// func([]K) ([]V, []error), whose calls are merged into batches of at most
+// maxBatch keys, held for at most wait.
+func (d *dispatcher) merge(fetch interface{}, maxBatch int, wait time.Duration) interface{} {
+	c := &collector{
+		d:        d,
+		fetch:    reflect.ValueOf(fetch),
+		maxBatch: maxBatch,
+		wait:     wait,
+	}
+	return reflect.MakeFunc(c.fetch.Type(), c.call).Interface()
+}
+
+func (c *collector) call(args []reflect.Value) []reflect.Value {
+	keys := args[0]
+	c.mu.Lock()
+	b := c.batch
+	if b == nil {
+		b = &mergedBatch{
+			keys: reflect.MakeSlice(keys.Type(), 0, keys.Len()),
+			done: make(chan struct{}),
+		}
+		b.timer = time.AfterFunc(c.wait, func() { c.dispatch(b) })
+		c.batch = b
+		c.d.hold(c)
+	}
+	start := b.keys.Len()
+	b.keys = reflect.AppendSlice(b.keys, keys)
+	end := b.keys.Len()
+	full := c.maxBatch > 0 && end >= c.maxBatch
+	c.mu.Unlock()
+	c.d.call(keys.Len())
+	defer c.d.call(-keys.Len())
+	if full {
+		c.dispatch(b)
+	}
+	<-b.done
+
+	values := reflect.Zero(c.fetch.Type().Out(0))
+	if b.values.Len() == b.keys.Len() {
+		values = b.values.Slice(start, end)
+	}
+	// a single error applies to all keys
+	errs := b.errs
+	if len(errs) > 1 {
+		errs = errs[start:end]
+	}
+	return []reflect.Value{values, reflect.ValueOf(errs)}
+}
+
+// dispatch fetches b, or the current batch if b is nil, unless it has been
+// dispatched already.
+func (c *collector) dispatch(b *mergedBatch) {
+	c.mu.Lock()
+	if c.batch == nil || (b != nil && c.batch != b) {
+		c.mu.Unlock()
+		return
+	}
+	b = c.batch
+	c.batch = nil
+	c.d.release(c)
+	c.mu.Unlock()
+	b.timer.Stop()
+
+	out := c.fetch.Call([]reflect.Value{b.keys})
+	b.values = out[0]
+	b.errs, _ = out[1].Interface().([]error)
+	close(b.done)
+}
@@ -9,10 +9,10 @@ import (
 	"github.com/fwojciec/gqlgen-sqlc-example/pg"
 )
 
-// AuthorSliceLoaderConfig captures the config to create a new AuthorSliceLoader
-type AuthorSliceLoaderConfig struct {
+// AuthorLoaderConfig captures the config to create a new AuthorLoader
+type AuthorLoaderConfig struct {
 	// Fetch is a method that provides the data for the loader
-	Fetch func(keys []int64) ([][]pg.Author, []error)
+	Fetch func(keys []int64) ([]*pg.Author, []error)
 
 	// Wait is how long wait before sending a batch
 	Wait time.Duration
@@ -21,19 +21,19 @@ type AuthorSliceLoaderConfig struct {
 	MaxBatch int
 }
 
-// NewAuthorSliceLoader creates a new AuthorSliceLoader given a fetch, wait, and maxBatch
-func NewAuthorSliceLoader(config AuthorSliceLoaderConfig) *AuthorSliceLoader {
-	return &AuthorSliceLoader{
+// NewAuthorLoader creates a new AuthorLoader given a fetch, wait, and maxBatch
+func NewAuthorLoader(config AuthorLoaderConfig) *AuthorLoader {
+	return &AuthorLoader{
 		fetch:    config.Fetch,
 		wait:     config.Wait,
 		maxBatch: config.MaxBatch,
 	}
 }
 
-// AuthorSliceLoader batches and caches requests
-type AuthorSliceLoader struct {
+// AuthorLoader batches and caches requests
+type AuthorLoader struct {
 	// this method provides the data for the loader
-	fetch func(keys []int64) ([][]pg.Author, []error)
+	fetch func(keys []int64) ([]*pg.Author, []error)
 
 	// how long to done before sending a batch
 	wait time.Duration
@@ -44,51 +44,51 @@ type AuthorSliceLoader struct {
 	// INTERNAL
 
 	// lazily created cache
-	cache map[int64][]pg.Author
+	cache map[int64]*pg.Author
 
 	// the current batch. keys will continue to be collected until timeout is hit,
 	// then everything will be sent to the fetch method and out to the listeners
-	batch *authorSliceLoaderBatch
+	batch *authorLoaderBatch
 
 	// mutex to prevent races
 	mu sync.Mutex
 }
 
-type authorSliceLoaderBatch struct {
+type authorLoaderBatch struct {
 	keys    []int64
-	data    [][]pg.Author
+	data    []*pg.Author
 	error   []error
 	closing bool
 	done    chan struct{}
 }
 
 // Load a Author by key, batching and caching will be applied automatically
-func (l *AuthorSliceLoader) Load(key int64) ([]pg.Author, error) {
+func (l *AuthorLoader) Load(key int64) (*pg.Author, error) {
 	return l.LoadThunk(key)()
 }
 
 // LoadThunk returns a function that when called will block waiting for a Author.
 // This method should be used if you want one goroutine to make requests to many
 // different data loaders without blocking until the thunk is called.
-func (l *AuthorSliceLoader) LoadThunk(key int64) func() ([]pg.Author, error) {
+func (l *AuthorLoader) LoadThunk(key int64) func() (*pg.Author, error) {
 	l.mu.Lock()
 	if it, ok := l.cache[key]; ok {
 		l.mu.Unlock()
-		return func() ([]pg.Author, error) {
+		return func() (*pg.Author, error) {
 			return it, nil
 		}
 	}
 	if l.batch == nil {
-		l.batch = &authorSliceLoaderBatch{done: make(chan struct{})}
+		l.batch = &authorLoaderBatch{done: make(chan struct{})}
 	}
 	batch := l.batch
 	pos := batch.keyIndex(l, key)
 	l.mu.Unlock()
 
-	return func() ([]pg.Author, error) {
+	return func() (*pg.Author, error) {
 		<-batch.done
 
-		var data []pg.Author
+		var data *pg.Author
 		if pos < len(batch.data) {
 			data = batch.data[pos]
 		}
@@ -113,14 +113,14 @@ func (l *AuthorSliceLoader) LoadThunk(key int64) func() ([]pg.Author, error) {
 
 // LoadAll fetches many keys at once. It will be broken into appropriate sized
 // sub batches depending on how the loader is configured
-func (l *AuthorSliceLoader) LoadAll(keys []int64) ([][]pg.Author, []error) {
-	results := make([]func() ([]pg.Author, error), len(keys))
+func (l *AuthorLoader) LoadAll(keys []int64) ([]*pg.Author, []error) {
+	results := make([]func() (*pg.Author, error), len(keys))
 
 	for i, key := range keys {
 		results[i] = l.LoadThunk(key)
 	}
 
-	authors := make([][]pg.Author, len(keys))
+	authors := make([]*pg.Author, len(keys))
 	errors := make([]error, len(keys))
 	for i, thunk := range results {
 		authors[i], errors[i] = thunk()
@@ -131,13 +131,13 @@ func (l *AuthorSliceLoader) LoadAll(keys []int64) ([][]pg.Author, []error) {
 // LoadAllThunk returns a function that when called will block waiting for a Authors.
 // This method should be used if you want one goroutine to make requests to many
 // different data loaders without blocking until the thunk is called.
-func (l *AuthorSliceLoader) LoadAllThunk(keys []int64) func() ([][]pg.Author, []error) {
-	results := make([]func() ([]pg.Author, error), len(keys))
+func (l *AuthorLoader) LoadAllThunk(keys []int64) func() ([]*pg.Author, []error) {
+	results := make([]func() (*pg.Author, error), len(keys))
 	for i, key := range keys {
 		results[i] = l.LoadThunk(key)
 	}
-	return func() ([][]pg.Author, []error) {
-		authors := make([][]pg.Author, len(keys))
+	return func() ([]*pg.Author, []error) {
+		authors := make([]*pg.Author, len(keys))
 		errors := make([]error, len(keys))
 		for i, thunk := range results {
 			authors[i], errors[i] = thunk()
@@ -149,37 +149,36 @@ func (l *AuthorSliceLoader) LoadAllThunk(keys []int64) func() ([][]pg.Author, []
 // Prime the cache with the provided key and value. If the key already exists, no change is made
 // and false is returned.
 // (To forcefully prime the cache, clear the key first with loader.clear(key).prime(key, value).)
-func (l *AuthorSliceLoader) Prime(key int64, value []pg.Author) bool {
+func (l *AuthorLoader) Prime(key int64, value *pg.Author) bool {
 	l.mu.Lock()
 	var found bool
 	if _, found = l.cache[key]; !found {
 		// make a copy when writing to the cache, its easy to pass a pointer in from a loop var
 		// and end up with the whole cache pointing to the same value.
-		cpy := make([]pg.Author, len(value))
-		copy(cpy, value)
-		l.unsafeSet(key, cpy)
+		cpy := *value
+		l.unsafeSet(key, &cpy)
 	}
 	l.mu.Unlock()
 	return !found
 }
 
 // Clear the value at key from the cache, if it exists
-func (l *AuthorSliceLoader) Clear(key int64) {
+func (l *AuthorLoader) Clear(key int64) {
 	l.mu.Lock()
 	delete(l.cache, key)
 	l.mu.Unlock()
 }
 
-func (l *AuthorSliceLoader) unsafeSet(key int64, value []pg.Author) {
+func (l *AuthorLoader) unsafeSet(key int64, value *pg.Author) {
 	if l.cache == nil {
-		l.cache = map[int64][]pg.Author{}
+		l.cache = map[int64]*pg.Author{}
 	}
 	l.cache[key] = value
 }
 
 // keyIndex will return the location of the key in the batch, if its not found
 // it will add the key to the batch
-func (b *authorSliceLoaderBatch) keyIndex(l *AuthorSliceLoader, key int64) int {
+func (b *authorLoaderBatch) keyIndex(l *AuthorLoader, key int64) int {
 	for i, existingKey := range b.keys {
 		if key == existingKey {
 			return i
@@ -203,7 +202,7 @@ func (b *authorSliceLoaderBatch) keyIndex(l *AuthorSliceLoader, key int64) int {
 	return pos
 }
 
-func (b *authorSliceLoaderBatch) startTimer(l *AuthorSliceLoader) {
+func (b *authorLoaderBatch) startTimer(l *AuthorLoader) {
 	time.Sleep(l.wait)
 	l.mu.Lock()
 
@@ -219,7 +218,7 @@ func (b *authorSliceLoaderBatch) startTimer(l *AuthorSliceLoader) {
 	b.end(l)
 }
 
-func (b *authorSliceLoaderBatch) end(l *AuthorSliceLoader) {
+func (b *authorLoaderBatch) end(l *AuthorLoader) {
 	b.data, b.error = l.fetch(b.keys)
 	close(b.done)
 }
@@ -9,10 +9,10 @@ import (
 	"github.com/fwojciec/gqlgen-sqlc-example/pg"
 )
 
-// BookSliceLoaderConfig captures the config to create a new BookSliceLoader
-type BookSliceLoaderConfig struct {
+// BookLoaderConfig captures the config to create a new BookLoader
+type BookLoaderConfig struct {
 	// Fetch is a method that provides the data for the loader
-	Fetch func(keys []int64) ([][]pg.Book, []error)
+	Fetch func(keys []int64) ([]*pg.Book, []error)
 
 	// Wait is how long wait before sending a batch
 	Wait time.Duration
@@ -21,19 +21,19 @@ type BookSliceLoaderConfig struct {
 	MaxBatch int
 }
 
-// NewBookSliceLoader creates a new BookSliceLoader given a fetch, wait, and maxBatch
-func NewBookSliceLoader(config BookSliceLoaderConfig) *BookSliceLoader {
-	return &BookSliceLoader{
+// NewBookLoader creates a new BookLoader given a fetch, wait, and maxBatch
+func NewBookLoader(config BookLoaderConfig) *BookLoader {
+	return &BookLoader{
 		fetch:    config.Fetch,
 		wait:     config.Wait,
 		maxBatch: config.MaxBatch,
 	}
 }
 
-// BookSliceLoader batches and caches requests
-type BookSliceLoader struct {
+// BookLoader batches and caches requests
+type BookLoader struct {
 	// this method provides the data for the loader
-	fetch func(keys []int64) ([][]pg.Book, []error)
+	fetch func(keys []int64) ([]*pg.Book, []error)
 
 	// how long to done before sending a batch
 	wait time.Duration
@@ -44,51 +44,51 @@ type BookSliceLoader struct {
 	// INTERNAL
 
 	// lazily created cache
-	cache map[int64][]pg.Book
+	cache map[int64]*pg.Book
 
 	// the current batch. keys will continue to be collected until timeout is hit,
 	// then everything will be sent to the fetch method and out to the listeners
-	batch *bookSliceLoaderBatch
+	batch *bookLoaderBatch
 
 	// mutex to prevent races
 	mu sync.Mutex
 }
 
-type bookSliceLoaderBatch struct {
+type bookLoaderBatch struct {
 	keys    []int64
-	data    [][]pg.Book
+	data    []*pg.Book
 	error   []error
 	closing bool
 	done    chan struct{}
 }
 
 // Load a Book by key, batching and caching will be applied automatically
-func (l *BookSliceLoader) Load(key int64) ([]pg.Book, error) {
+func (l *BookLoader) Load(key int64) (*pg.Book, error) {
 	return l.LoadThunk(key)()
 }
 
 // LoadThunk returns a function that when called will block waiting for a Book.
 // This method should be used if you want one goroutine to make requests to many
 // different data loaders without blocking until the thunk is called.
-func (l *BookSliceLoader) LoadThunk(key int64) func() ([]pg.Book, error) {
+func (l *BookLoader) LoadThunk(key int64) func() (*pg.Book, error) {
 	l.mu.Lock()
 	if it, ok := l.cache[key]; ok {
 		l.mu.Unlock()
-		return func() ([]pg.Book, error) {
+		return func() (*pg.Book, error) {
 			return it, nil
 		}
 	}
 	if l.batch == nil {
-		l.batch = &bookSliceLoaderBatch{done: make(chan struct{})}
+		l.batch = &bookLoaderBatch{done: make(chan struct{})}
 	}
 	batch := l.batch
 	pos := batch.keyIndex(l, key)
 	l.mu.Unlock()
 
-	return func() ([]pg.Book, error) {
+	return func() (*pg.Book, error) {
 		<-batch.done
 
-		var data []pg.Book
+		var data *pg.Book
 		if pos < len(batch.data) {
 			data = batch.data[pos]
 		}
@@ -113,14 +113,14 @@ func (l *BookSliceLoader) LoadThunk(key int64) func() ([]pg.Book, error) {
 
 // LoadAll fetches many keys at once. It will be broken into appropriate sized
 // sub batches depending on how the loader is configured
-func (l *BookSliceLoader) LoadAll(keys []int64) ([][]pg.Book, []error) {
-	results := make([]func() ([]pg.Book, error), len(keys))
+func (l *BookLoader) LoadAll(keys []int64) ([]*pg.Book, []error) {
+	results := make([]func() (*pg.Book, error), len(keys))
 
 	for i, key := range keys {
 		results[i] = l.LoadThunk(key)
 	}
 
-	books := make([][]pg.Book, len(keys))
+	books := make([]*pg.Book, len(keys))
 	errors := make([]error, len(keys))
 	for i, thunk := range results {
 		books[i], errors[i] = thunk()
@@ -131,13 +131,13 @@ func (l *BookSliceLoader) LoadAll(keys []int64) ([][]pg.Book, []error) {
 // LoadAllThunk returns a function that when called will block waiting for a Books.
 // This method should be used if you want one goroutine to make requests to many
 // different data loaders without blocking until the thunk is called.
-func (l *BookSliceLoader) LoadAllThunk(keys []int64) func() ([][]pg.Book, []error) {
-	results := make([]func() ([]pg.Book, error), len(keys))
+func (l *BookLoader) LoadAllThunk(keys []int64) func() ([]*pg.Book, []error) {
+	results := make([]func() (*pg.Book, error), len(keys))
 	for i, key := range keys {
 		results[i] = l.LoadThunk(key)
 	}
-	return func() ([][]pg.Book, []error) {
-		books := make([][]pg.Book, len(keys))
+	return func() ([]*pg.Book, []error) {
+		books := make([]*pg.Book, len(keys))
 		errors := make([]error, len(keys))
 		for i, thunk := range results {
 			books[i], errors[i] = thunk()
@@ -149,37 +149,36 @@ func (l *BookSliceLoader) LoadAllThunk(keys []int64) func() ([][]pg.Book, []erro
 // Prime the cache with the provided key and value. If the key already exists, no change is made
 // and false is returned.
 // (To forcefully prime the cache, clear the key first with loader.clear(key).prime(key, value).)
-func (l *BookSliceLoader) Prime(key int64, value []pg.Book) bool {
+func (l *BookLoader) Prime(key int64, value *pg.Book) bool {
 	l.mu.Lock()
 	var found bool
 	if _, found = l.cache[key]; !found {
 		// make a copy when writing to the cache, its easy to pass a pointer in from a loop var
 		// and end up with the whole cache pointing to the same value.
-		cpy := make([]pg.Book, len(value))
-		copy(cpy, value)
-		l.unsafeSet(key, cpy)
+		cpy := *value
+		l.unsafeSet(key, &cpy)
 	}
 	l.mu.Unlock()
 	return !found
 }
 
 // Clear the value at key from the cache, if it exists
-func (l *BookSliceLoader) Clear(key int64) {
+func (l *BookLoader) Clear(key int64) {
 	l.mu.Lock()
 	delete(l.cache, key)
 	l.mu.Unlock()
 }
 
-func (l *BookSliceLoader) unsafeSet(key int64, value []pg.Book) {
+func (l *BookLoader) unsafeSet(key int64, value *pg.Book) {
 	if l.cache == nil {
-		l.cache = map[int64][]pg.Book{}
+		l.cache = map[int64]*pg.Book{}
 	}
 	l.cache[key] = value
 }
 
 // keyIndex will return the location of the key in the batch, if its not found
 // it will add the key to the batch
-func (b *bookSliceLoaderBatch) keyIndex(l *BookSliceLoader, key int64) int {
+func (b *bookLoaderBatch) keyIndex(l *BookLoader, key int64) int {
 	for i, existingKey := range b.keys {
 		if key == existingKey {
 			return i
@@ -203,7 +202,7 @@ func (b *bookSliceLoaderBatch) keyIndex(l *BookSliceLoader, key int64) int {
 	return pos
 }
 
-func (b *bookSliceLoaderBatch) startTimer(l *BookSliceLoader) {
+func (b *bookLoaderBatch) startTimer(l *BookLoader) {
 	time.Sleep(l.wait)
 	l.mu.Lock()
 
@@ -219,7 +218,7 @@ func (b *bookSliceLoaderBatch) startTimer(l *BookSliceLoader) {
 	b.end(l)
 }
 
-func (b *bookSliceLoaderBatch) end(l *BookSliceLoader) {
+func (b *bookLoaderBatch) end(l *BookLoader) {
 	b.data, b.error = l.fetch(b.keys)
 	close(b.done)
 }
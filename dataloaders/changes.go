@@ -0,0 +1,152 @@
+package dataloaders
+
+import (
+	"sync"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+// The methods below update the loaders of a request after a mutation, so
+// that the fields resolved later in the same request see its changes: the
+// loaders by ID are primed with the changed entity, and the cached values
+// which may list it, on both sides of its relationships, are cleared.
+//
+// The connections listing a changed child are cleared for all parents, as
+// a rename or a move to another parent changes the pages of parents which
+// did not list the child before.
+
+// AgentChanged updates the loaders after agent was created, updated, deleted
+// or restored.
+func (l *Loaders) AgentChanged(agent *pg.Agent) {
+	l.AgentByID.Clear(agent.ID)
+	l.AgentByID.Prime(agent.ID, agent)
+	// the authors keep their agent
+	for _, authorID := range l.agentAuthorIDs.get(agent.ID) {
+		l.AgentByAuthorID.Clear(authorID)
+		l.AgentByAuthorID.Prime(authorID, agent)
+	}
+	for _, key := range l.historyByAgentIDKeys.take(agent.ID) {
+		l.HistoryByAgentID.Clear(key)
+	}
+}
+
+// AuthorChanged updates the loaders after author was created, updated,
+// deleted or restored.
+func (l *Loaders) AuthorChanged(author *pg.Author) {
+	l.AuthorByID.Clear(author.ID)
+	l.AuthorByID.Prime(author.ID, author)
+	// the agent may have changed, it is loaded again when needed
+	l.AgentByAuthorID.Clear(author.ID)
+	l.agentAuthorIDs.forget(author.ID)
+	for _, key := range l.authorsByAgentIDKeys.takeAll() {
+		l.AuthorsByAgentID.Clear(key)
+	}
+	for _, key := range l.authorsByBookIDKeys.takeAll() {
+		l.AuthorsByBookID.Clear(key)
+	}
+	for _, key := range l.historyByAuthorIDKeys.take(author.ID) {
+		l.HistoryByAuthorID.Clear(key)
+	}
+}
+
+// BookChanged updates the loaders after book was created, updated, deleted
+// or restored.
+func (l *Loaders) BookChanged(book *pg.Book) {
+	l.BookByID.Clear(book.ID)
+	l.BookByID.Prime(book.ID, book)
+	for _, key := range l.authorsByBookIDKeys.take(book.ID) {
+		l.AuthorsByBookID.Clear(key)
+	}
+	for _, key := range l.booksByAuthorIDKeys.takeAll() {
+		l.BooksByAuthorID.Clear(key)
+	}
+	for _, key := range l.historyByBookIDKeys.take(book.ID) {
+		l.HistoryByBookID.Clear(key)
+	}
+}
+
+// pageKeys records the keys fetched by a connection loader, which has no way
+// to list its cached keys, so that they can be cleared.
+type pageKeys struct {
+	mu   sync.Mutex
+	keys map[PageKey]bool
+}
+
+func newPageKeys() *pageKeys {
+	return &pageKeys{keys: make(map[PageKey]bool)}
+}
+
+func (k *pageKeys) add(keys []PageKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for _, key := range keys {
+		k.keys[key] = true
+	}
+}
+
+// take returns and forgets the keys of the parent with the given id.
+func (k *pageKeys) take(id int64) []PageKey {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	var keys []PageKey
+	for key := range k.keys {
+		if key.ID == id {
+			keys = append(keys, key)
+			delete(k.keys, key)
+		}
+	}
+	return keys
+}
+
+// takeAll returns and forgets all keys.
+func (k *pageKeys) takeAll() []PageKey {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	keys := make([]PageKey, 0, len(k.keys))
+	for key := range k.keys {
+		keys = append(keys, key)
+	}
+	k.keys = make(map[PageKey]bool)
+	return keys
+}
+
+// ownerKeys records the keys fetched by a loader by the id of the entity
+// they were resolved to, e.g. the authors loaded by AgentByAuthorID by the
+// id of their agent.
+type ownerKeys struct {
+	mu   sync.Mutex
+	keys map[int64]map[int64]bool
+}
+
+func newOwnerKeys() *ownerKeys {
+	return &ownerKeys{keys: make(map[int64]map[int64]bool)}
+}
+
+func (k *ownerKeys) add(owner, key int64) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.keys[owner] == nil {
+		k.keys[owner] = make(map[int64]bool)
+	}
+	k.keys[owner][key] = true
+}
+
+// get returns the keys of owner.
+func (k *ownerKeys) get(owner int64) []int64 {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	var keys []int64
+	for key := range k.keys[owner] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// forget forgets key, whatever its owner.
+func (k *ownerKeys) forget(key int64) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for _, keys := range k.keys {
+		delete(keys, key)
+	}
+}
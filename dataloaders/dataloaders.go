@@ -1,11 +1,19 @@
 package dataloaders
 
 //go:generate go run github.com/vektah/dataloaden AgentLoader int64 *github.com/fwojciec/gqlgen-sqlc-example/pg.Agent
-//go:generate go run github.com/vektah/dataloaden AuthorSliceLoader int64 []github.com/fwojciec/gqlgen-sqlc-example/pg.Author
-//go:generate go run github.com/vektah/dataloaden BookSliceLoader int64 []github.com/fwojciec/gqlgen-sqlc-example/pg.Book
+//go:generate go run github.com/vektah/dataloaden AuthorLoader int64 *github.com/fwojciec/gqlgen-sqlc-example/pg.Author
+//go:generate go run github.com/vektah/dataloaden BookLoader int64 *github.com/fwojciec/gqlgen-sqlc-example/pg.Book
+//go:generate go run github.com/vektah/dataloaden AuthorConnectionLoader github.com/fwojciec/gqlgen-sqlc-example/dataloaders.PageKey *github.com/fwojciec/gqlgen-sqlc-example/pg.AuthorConnection
+//go:generate go run github.com/vektah/dataloaden BookConnectionLoader github.com/fwojciec/gqlgen-sqlc-example/dataloaders.PageKey *github.com/fwojciec/gqlgen-sqlc-example/pg.BookConnection
+//go:generate go run github.com/vektah/dataloaden AuditEntryConnectionLoader github.com/fwojciec/gqlgen-sqlc-example/dataloaders.PageKey *github.com/fwojciec/gqlgen-sqlc-example/pg.AuditEntryConnection
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
@@ -18,20 +26,283 @@ const key = contextKey("dataloaders")
 // Loaders holds references to the individual dataloaders.
 type Loaders struct {
 	// individual loaders will be defined here
-	AgentByAuthorID  *AgentLoader
-	AuthorsByAgentID *AuthorSliceLoader
-	AuthorsByBookID  *AuthorSliceLoader
-	BooksByAuthorID  *BookSliceLoader
+	AgentByID         *AgentLoader
+	AuthorByID        *AuthorLoader
+	BookByID          *BookLoader
+	AgentByAuthorID   *AgentLoader
+	AuthorsByAgentID  *AuthorConnectionLoader
+	AuthorsByBookID   *AuthorConnectionLoader
+	BooksByAuthorID   *BookConnectionLoader
+	HistoryByAgentID  *AuditEntryConnectionLoader
+	HistoryByAuthorID *AuditEntryConnectionLoader
+	HistoryByBookID   *AuditEntryConnectionLoader
+
+	// the keys fetched by the loaders, see AgentChanged
+	agentAuthorIDs        *ownerKeys
+	authorsByAgentIDKeys  *pageKeys
+	authorsByBookIDKeys   *pageKeys
+	booksByAuthorIDKeys   *pageKeys
+	historyByAgentIDKeys  *pageKeys
+	historyByAuthorIDKeys *pageKeys
+	historyByBookIDKeys   *pageKeys
+}
+
+// Config holds the batching settings of the loaders.
+type Config struct {
+	// MaxBatch is the maximum number of keys fetched in one batch, 0 means
+	// no limit.
+	MaxBatch int
+	// Wait is how long a loader waits for more keys before fetching a batch.
+	// In adaptive mode it is the longest a loader waits.
+	Wait time.Duration
+	// Loaders overrides the settings above for individual loaders, by the
+	// name of their field in Loaders, e.g. "BooksByAuthorID".
+	Loaders map[string]LoaderConfig
+	// Adaptive makes the loaders fetch their batches as soon as the
+	// resolvers of the request all wait for loaders, rather than after
+	// Wait. It requires the handler options returned by HandlerOptions.
+	Adaptive bool
+	// Observer is notified about the fetched batches, it may be nil.
+	Observer BatchObserver
+}
+
+// LoaderConfig overrides the batching settings of a loader, the nil fields
+// keep the settings of Config.
+type LoaderConfig struct {
+	MaxBatch *int
+	Wait     *time.Duration
 }
 
-func newLoaders(ctx context.Context, repo pg.Repository) *Loaders {
-	return &Loaders{
-		// individual loaders will be initialized here
-		AgentByAuthorID:  newAgentByAuthorID(ctx, repo),
-		AuthorsByAgentID: newAuthorsByAgentID(ctx, repo),
-		AuthorsByBookID:  newAuthorsByBookID(ctx, repo),
-		BooksByAuthorID:  newBooksByAuthorID(ctx, repo),
+// loaderNames lists the loaders which can be configured individually.
+var loaderNames = []string{
+	"AgentByID",
+	"AuthorByID",
+	"BookByID",
+	"AgentByAuthorID",
+	"AuthorsByAgentID",
+	"AuthorsByBookID",
+	"BooksByAuthorID",
+	"HistoryByAgentID",
+	"HistoryByAuthorID",
+	"HistoryByBookID",
+}
+
+// Validate checks the settings, including the names of the loaders
+// configured individually.
+func (c Config) Validate() error {
+	var problems []string
+	check := func(name string, maxBatch int, wait time.Duration) {
+		if maxBatch < 0 {
+			problems = append(problems, name+"max batch must not be negative")
+		}
+		if wait < 0 {
+			problems = append(problems, name+"wait must not be negative")
+		}
+	}
+	check("", c.MaxBatch, c.Wait)
+	names := make([]string, 0, len(c.Loaders))
+	for name := range c.Loaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		known := false
+		for _, n := range loaderNames {
+			known = known || n == name
+		}
+		if !known {
+			problems = append(problems, fmt.Sprintf("unknown loader %q", name))
+			continue
+		}
+		lc := c.loader(name, nil)
+		check(name+" ", lc.maxBatch, lc.wait)
 	}
+	if len(problems) > 0 {
+		return errors.New("dataloaders: invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// loaderConfig holds the settings of a single loader.
+type loaderConfig struct {
+	name       string
+	maxBatch   int
+	wait       time.Duration
+	observer   BatchObserver
+	dispatcher *dispatcher // nil unless adaptive
+}
+
+// loader returns the settings of the named loader.
+func (c Config) loader(name string, d *dispatcher) loaderConfig {
+	lc := loaderConfig{
+		name:       name,
+		maxBatch:   c.MaxBatch,
+		wait:       c.Wait,
+		observer:   c.Observer,
+		dispatcher: d,
+	}
+	if o, ok := c.Loaders[name]; ok {
+		if o.MaxBatch != nil {
+			lc.maxBatch = *o.MaxBatch
+		}
+		if o.Wait != nil {
+			lc.wait = *o.Wait
+		}
+	}
+	return lc
+}
+
+// batchMax returns the maximum batch of the generated loader: in adaptive
+// mode it hands every key over to the collector separately, so that the
+// dispatcher can count them.
+func (c loaderConfig) batchMax() int {
+	if c.dispatcher != nil {
+		return 1
+	}
+	return c.maxBatch
+}
+
+// batchWait returns the wait of the generated loader: in adaptive mode it
+// fetches without waiting and the collector holds the batch instead.
+func (c loaderConfig) batchWait() time.Duration {
+	if c.dispatcher != nil {
+		return 0
+	}
+	return c.wait
+}
+
+// merge returns fetch, merged into batches by the dispatcher in adaptive
+// mode. The result has the type of fetch.
+func (c loaderConfig) merge(fetch interface{}) interface{} {
+	if c.dispatcher == nil {
+		return fetch
+	}
+	return c.dispatcher.merge(fetch, c.maxBatch, c.wait)
+}
+
+// BatchObserver is notified about the batches fetched by the loaders, e.g. to
+// record metrics.
+type BatchObserver interface {
+	// StartBatch is called before a loader fetches a batch of keys. The
+	// returned context is used for the fetch and the returned function is
+	// called with the error of the fetch, if any, once it completes.
+	StartBatch(ctx context.Context, loader string, keys int) (context.Context, func(error))
+}
+
+// Observers combines several observers into one. They are started in the
+// given order and ended in reverse order.
+func Observers(observers ...BatchObserver) BatchObserver {
+	return multiObserver(observers)
+}
+
+type multiObserver []BatchObserver
+
+func (m multiObserver) StartBatch(ctx context.Context, loader string, keys int) (context.Context, func(error)) {
+	ends := make([]func(error), len(m))
+	for i, o := range m {
+		ctx, ends[i] = o.StartBatch(ctx, loader, keys)
+	}
+	return ctx, func(err error) {
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](err)
+		}
+	}
+}
+
+func (c loaderConfig) startBatch(ctx context.Context, keys int) (context.Context, func([]error)) {
+	if c.observer == nil {
+		return ctx, func([]error) {}
+	}
+	ctx, end := c.observer.StartBatch(ctx, c.name, keys)
+	return ctx, func(errs []error) {
+		var err error
+		if len(errs) > 0 {
+			err = errs[0]
+		}
+		end(err)
+	}
+}
+
+// DefaultConfig returns the default batching settings.
+func DefaultConfig() Config {
+	return Config{
+		MaxBatch: 100,
+		Wait:     5 * time.Millisecond,
+	}
+}
+
+// PageKey identifies a page of a parent's nested connection.
+type PageKey struct {
+	ID   int64
+	Page pg.Page
+}
+
+func newLoaders(ctx context.Context, repo pg.Repository, cfg Config, d *dispatcher) *Loaders {
+	l := &Loaders{
+		agentAuthorIDs:        newOwnerKeys(),
+		authorsByAgentIDKeys:  newPageKeys(),
+		authorsByBookIDKeys:   newPageKeys(),
+		booksByAuthorIDKeys:   newPageKeys(),
+		historyByAgentIDKeys:  newPageKeys(),
+		historyByAuthorIDKeys: newPageKeys(),
+		historyByBookIDKeys:   newPageKeys(),
+	}
+	// individual loaders will be initialized here
+	l.AgentByID = newAgentByID(ctx, repo, cfg.loader("AgentByID", d))
+	l.AuthorByID = newAuthorByID(ctx, repo, cfg.loader("AuthorByID", d))
+	l.BookByID = newBookByID(ctx, repo, cfg.loader("BookByID", d))
+	// the relationship loaders prime the loaders by ID with the entities they
+	// fetch
+	l.AgentByAuthorID = newAgentByAuthorID(ctx, repo, cfg.loader("AgentByAuthorID", d), l.AgentByID, l.agentAuthorIDs)
+	l.AuthorsByAgentID = newAuthorsByAgentID(ctx, repo, cfg.loader("AuthorsByAgentID", d), l.AuthorByID, l.authorsByAgentIDKeys)
+	l.AuthorsByBookID = newAuthorsByBookID(ctx, repo, cfg.loader("AuthorsByBookID", d), l.AuthorByID, l.authorsByBookIDKeys)
+	l.BooksByAuthorID = newBooksByAuthorID(ctx, repo, cfg.loader("BooksByAuthorID", d), l.BookByID, l.booksByAuthorIDKeys)
+	l.HistoryByAgentID = newHistoryByEntityID(ctx, repo, cfg.loader("HistoryByAgentID", d), pg.EntityAgent, l.historyByAgentIDKeys)
+	l.HistoryByAuthorID = newHistoryByEntityID(ctx, repo, cfg.loader("HistoryByAuthorID", d), pg.EntityAuthor, l.historyByAuthorIDKeys)
+	l.HistoryByBookID = newHistoryByEntityID(ctx, repo, cfg.loader("HistoryByBookID", d), pg.EntityBook, l.historyByBookIDKeys)
+	return l
+}
+
+// scope holds the loaders of a request, or of a subscription.
+type scope struct {
+	mu         sync.Mutex
+	loaders    *Loaders
+	new        func() *Loaders
+	create     func(ctx context.Context, d *dispatcher) *Loaders
+	dispatcher *dispatcher // nil unless adaptive
+}
+
+// newScope returns a scope whose loaders are created by create for ctx and
+// d.
+func newScope(ctx context.Context, create func(ctx context.Context, d *dispatcher) *Loaders, d *dispatcher) *scope {
+	s := &scope{create: create, dispatcher: d}
+	s.new = func() *Loaders { return create(ctx, d) }
+	s.loaders = s.new()
+	return s
+}
+
+// Reset replaces the loaders of the request with empty ones, so that
+// subsequent loads return the current data. Subscriptions reset theirs
+// before every event, as they last as long as the subscription.
+func Reset(ctx context.Context) {
+	if s, ok := ctx.Value(key).(*scope); ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.loaders = s.new()
+	}
+}
+
+// ForSubscription returns a copy of ctx holding loaders of their own for a
+// subscription, so that resetting them does not affect the other operations
+// of the request, e.g. those sent over the same websocket connection. The
+// fields of the events are not announced to a dispatcher, so the loaders
+// wait for Wait in adaptive mode too.
+func ForSubscription(ctx context.Context) context.Context {
+	s, ok := ctx.Value(key).(*scope)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, key, newScope(ctx, s.create, nil))
 }
 
 // Retriever retrieves dataloaders from the request context.
@@ -44,7 +315,13 @@ type retriever struct {
 }
 
 func (r *retriever) Retrieve(ctx context.Context) *Loaders {
-	return ctx.Value(r.key).(*Loaders)
+	s := ctx.Value(r.key).(*scope)
+	if s.dispatcher != nil {
+		s.dispatcher.load(ctx)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loaders
 }
 
 // NewRetriever instantiates a new implementation of Retriever.
@@ -52,116 +329,318 @@ func NewRetriever() Retriever {
 	return &retriever{key: key}
 }
 
-func newAgentByAuthorID(ctx context.Context, repo pg.Repository) *AgentLoader {
+// The loaders by ID return the deleted entities as well, it is up to the
+// resolvers to hide them.
+
+func newAgentByID(ctx context.Context, repo pg.Repository, cfg loaderConfig) *AgentLoader {
 	return NewAgentLoader(AgentLoaderConfig{
-		MaxBatch: 100,
-		Wait:     5 * time.Millisecond,
-		Fetch: func(authorIDs []int64) ([]*pg.Agent, []error) {
+		MaxBatch: cfg.batchMax(),
+		Wait:     cfg.batchWait(),
+		Fetch: cfg.merge(func(ids []int64) (_ []*pg.Agent, errs []error) {
+			ctx, done := cfg.startBatch(ctx, len(ids))
+			defer func() { done(errs) }()
 			// db query
-			res, err := repo.ListAgentsByAuthorIDs(ctx, authorIDs)
+			res, err := repo.ListAgentsByIDs(ctx, ids)
 			if err != nil {
 				return nil, []error{err}
 			}
 			// map
-			groupByAuthorID := make(map[int64]*pg.Agent, len(authorIDs))
-			for _, r := range res {
-				groupByAuthorID[r.AuthorID] = &pg.Agent{
-					ID:    r.ID,
-					Name:  r.Name,
-					Email: r.Email,
-				}
+			byID := make(map[int64]*pg.Agent, len(ids))
+			for i := range res {
+				byID[res[i].ID] = &res[i]
 			}
 			// order
-			result := make([]*pg.Agent, len(authorIDs))
-			for i, authorID := range authorIDs {
-				result[i] = groupByAuthorID[authorID]
+			result := make([]*pg.Agent, len(ids))
+			for i, id := range ids {
+				result[i] = byID[id]
 			}
 			return result, nil
-		},
+		}).(func([]int64) ([]*pg.Agent, []error)),
 	})
 }
 
-func newAuthorsByAgentID(ctx context.Context, repo pg.Repository) *AuthorSliceLoader {
-	return NewAuthorSliceLoader(AuthorSliceLoaderConfig{
-		MaxBatch: 100,
-		Wait:     5 * time.Millisecond,
-		Fetch: func(agentIDs []int64) ([][]pg.Author, []error) {
+func newAuthorByID(ctx context.Context, repo pg.Repository, cfg loaderConfig) *AuthorLoader {
+	return NewAuthorLoader(AuthorLoaderConfig{
+		MaxBatch: cfg.batchMax(),
+		Wait:     cfg.batchWait(),
+		Fetch: cfg.merge(func(ids []int64) (_ []*pg.Author, errs []error) {
+			ctx, done := cfg.startBatch(ctx, len(ids))
+			defer func() { done(errs) }()
 			// db query
-			res, err := repo.ListAuthorsByAgentIDs(ctx, agentIDs)
+			res, err := repo.ListAuthorsByIDs(ctx, ids)
 			if err != nil {
 				return nil, []error{err}
 			}
-			// group
-			groupByAgentID := make(map[int64][]pg.Author, len(agentIDs))
-			for _, r := range res {
-				groupByAgentID[r.AgentID] = append(groupByAgentID[r.AgentID], r)
+			// map
+			byID := make(map[int64]*pg.Author, len(ids))
+			for i := range res {
+				byID[res[i].ID] = &res[i]
 			}
 			// order
-			result := make([][]pg.Author, len(agentIDs))
-			for i, agentID := range agentIDs {
-				result[i] = groupByAgentID[agentID]
+			result := make([]*pg.Author, len(ids))
+			for i, id := range ids {
+				result[i] = byID[id]
 			}
 			return result, nil
-		},
+		}).(func([]int64) ([]*pg.Author, []error)),
 	})
 }
 
-func newAuthorsByBookID(ctx context.Context, repo pg.Repository) *AuthorSliceLoader {
-	return NewAuthorSliceLoader(AuthorSliceLoaderConfig{
-		MaxBatch: 100,
-		Wait:     5 * time.Millisecond,
-		Fetch: func(bookIDs []int64) ([][]pg.Author, []error) {
+func newBookByID(ctx context.Context, repo pg.Repository, cfg loaderConfig) *BookLoader {
+	return NewBookLoader(BookLoaderConfig{
+		MaxBatch: cfg.batchMax(),
+		Wait:     cfg.batchWait(),
+		Fetch: cfg.merge(func(ids []int64) (_ []*pg.Book, errs []error) {
+			ctx, done := cfg.startBatch(ctx, len(ids))
+			defer func() { done(errs) }()
 			// db query
-			res, err := repo.ListAuthorsByBookIDs(ctx, bookIDs)
+			res, err := repo.ListBooksByIDs(ctx, ids)
 			if err != nil {
 				return nil, []error{err}
 			}
-			// group
-			groupByBookID := make(map[int64][]pg.Author, len(bookIDs))
-			for _, r := range res {
-				groupByBookID[r.BookID] = append(groupByBookID[r.BookID], pg.Author{
-					ID:      r.ID,
-					Name:    r.Name,
-					Website: r.Website,
-					AgentID: r.AgentID,
-				})
+			// map
+			byID := make(map[int64]*pg.Book, len(ids))
+			for i := range res {
+				byID[res[i].ID] = &res[i]
 			}
 			// order
-			result := make([][]pg.Author, len(bookIDs))
-			for i, bookID := range bookIDs {
-				result[i] = groupByBookID[bookID]
+			result := make([]*pg.Book, len(ids))
+			for i, id := range ids {
+				result[i] = byID[id]
 			}
 			return result, nil
-		},
+		}).(func([]int64) ([]*pg.Book, []error)),
 	})
 }
 
-func newBooksByAuthorID(ctx context.Context, repo pg.Repository) *BookSliceLoader {
-	return NewBookSliceLoader(BookSliceLoaderConfig{
-		MaxBatch: 100,
-		Wait:     5 * time.Millisecond,
-		Fetch: func(authorIDs []int64) ([][]pg.Book, []error) {
+func newAgentByAuthorID(ctx context.Context, repo pg.Repository, cfg loaderConfig, agentByID *AgentLoader, fetched *ownerKeys) *AgentLoader {
+	return NewAgentLoader(AgentLoaderConfig{
+		MaxBatch: cfg.batchMax(),
+		Wait:     cfg.batchWait(),
+		Fetch: cfg.merge(func(authorIDs []int64) (_ []*pg.Agent, errs []error) {
+			ctx, done := cfg.startBatch(ctx, len(authorIDs))
+			defer func() { done(errs) }()
 			// db query
-			res, err := repo.ListBooksByAuthorIDs(ctx, authorIDs)
+			res, err := repo.ListAgentsByAuthorIDs(ctx, authorIDs)
 			if err != nil {
 				return nil, []error{err}
 			}
-			// group
-			groupByAuthorID := make(map[int64][]pg.Book, len(authorIDs))
+			// map
+			groupByAuthorID := make(map[int64]*pg.Agent, len(authorIDs))
 			for _, r := range res {
-				groupByAuthorID[r.AuthorID] = append(groupByAuthorID[r.AuthorID], pg.Book{
-					ID:          r.ID,
-					Title:       r.Title,
-					Description: r.Description,
-					Cover:       r.Cover,
-				})
+				agent := &pg.Agent{
+					ID:        r.ID,
+					Name:      r.Name,
+					Email:     r.Email,
+					DeletedAt: r.DeletedAt,
+					Version:   r.Version,
+				}
+				groupByAuthorID[r.AuthorID] = agent
+				agentByID.Prime(agent.ID, agent)
+				fetched.add(agent.ID, r.AuthorID)
 			}
 			// order
-			result := make([][]pg.Book, len(authorIDs))
+			result := make([]*pg.Agent, len(authorIDs))
 			for i, authorID := range authorIDs {
 				result[i] = groupByAuthorID[authorID]
 			}
 			return result, nil
-		},
+		}).(func([]int64) ([]*pg.Agent, []error)),
+	})
+}
+
+func newAuthorsByAgentID(ctx context.Context, repo pg.Repository, cfg loaderConfig, authorByID *AuthorLoader, fetched *pageKeys) *AuthorConnectionLoader {
+	return NewAuthorConnectionLoader(AuthorConnectionLoaderConfig{
+		MaxBatch: cfg.batchMax(),
+		Wait:     cfg.batchWait(),
+		Fetch: cfg.merge(func(keys []PageKey) (_ []*pg.AuthorConnection, errs []error) {
+			ctx, done := cfg.startBatch(ctx, len(keys))
+			defer func() { done(errs) }()
+			result := make([]*pg.AuthorConnection, len(keys))
+			for page, idxs := range groupByPage(keys) {
+				agentIDs := pageIDs(keys, idxs)
+				// db query
+				res, err := repo.ListAuthorsByAgentIDsPage(ctx, page.AuthorsByAgentIDsParams(agentIDs))
+				if err != nil {
+					return nil, []error{err}
+				}
+				counts, err := repo.CountAuthorsByAgentIDs(ctx, agentIDs)
+				if err != nil {
+					return nil, []error{err}
+				}
+				// group
+				groupByAgentID := make(map[int64][]pg.Author, len(agentIDs))
+				for _, r := range res {
+					author := pg.Author{
+						ID:      r.ID,
+						Name:    r.Name,
+						Website: r.Website,
+						AgentID: r.AgentID,
+						Version: r.Version,
+					}
+					groupByAgentID[r.AgentID] = append(groupByAgentID[r.AgentID], author)
+					authorByID.Prime(author.ID, &author)
+				}
+				countByAgentID := make(map[int64]int64, len(agentIDs))
+				for _, c := range counts {
+					countByAgentID[c.AgentID] = c.Count
+				}
+				// order
+				for _, i := range idxs {
+					agentID := keys[i].ID
+					result[i] = pg.NewAuthorConnection(page, groupByAgentID[agentID], countByAgentID[agentID])
+				}
+			}
+			fetched.add(keys)
+			return result, nil
+		}).(func([]PageKey) ([]*pg.AuthorConnection, []error)),
+	})
+}
+
+func newAuthorsByBookID(ctx context.Context, repo pg.Repository, cfg loaderConfig, authorByID *AuthorLoader, fetched *pageKeys) *AuthorConnectionLoader {
+	return NewAuthorConnectionLoader(AuthorConnectionLoaderConfig{
+		MaxBatch: cfg.batchMax(),
+		Wait:     cfg.batchWait(),
+		Fetch: cfg.merge(func(keys []PageKey) (_ []*pg.AuthorConnection, errs []error) {
+			ctx, done := cfg.startBatch(ctx, len(keys))
+			defer func() { done(errs) }()
+			result := make([]*pg.AuthorConnection, len(keys))
+			for page, idxs := range groupByPage(keys) {
+				bookIDs := pageIDs(keys, idxs)
+				// db query
+				res, err := repo.ListAuthorsByBookIDsPage(ctx, page.AuthorsByBookIDsParams(bookIDs))
+				if err != nil {
+					return nil, []error{err}
+				}
+				counts, err := repo.CountAuthorsByBookIDs(ctx, bookIDs)
+				if err != nil {
+					return nil, []error{err}
+				}
+				// group
+				groupByBookID := make(map[int64][]pg.Author, len(bookIDs))
+				for _, r := range res {
+					author := pg.Author{
+						ID:      r.ID,
+						Name:    r.Name,
+						Website: r.Website,
+						AgentID: r.AgentID,
+						Version: r.Version,
+					}
+					groupByBookID[r.BookID] = append(groupByBookID[r.BookID], author)
+					authorByID.Prime(author.ID, &author)
+				}
+				countByBookID := make(map[int64]int64, len(bookIDs))
+				for _, c := range counts {
+					countByBookID[c.BookID] = c.Count
+				}
+				// order
+				for _, i := range idxs {
+					bookID := keys[i].ID
+					result[i] = pg.NewAuthorConnection(page, groupByBookID[bookID], countByBookID[bookID])
+				}
+			}
+			fetched.add(keys)
+			return result, nil
+		}).(func([]PageKey) ([]*pg.AuthorConnection, []error)),
+	})
+}
+
+func newBooksByAuthorID(ctx context.Context, repo pg.Repository, cfg loaderConfig, bookByID *BookLoader, fetched *pageKeys) *BookConnectionLoader {
+	return NewBookConnectionLoader(BookConnectionLoaderConfig{
+		MaxBatch: cfg.batchMax(),
+		Wait:     cfg.batchWait(),
+		Fetch: cfg.merge(func(keys []PageKey) (_ []*pg.BookConnection, errs []error) {
+			ctx, done := cfg.startBatch(ctx, len(keys))
+			defer func() { done(errs) }()
+			result := make([]*pg.BookConnection, len(keys))
+			for page, idxs := range groupByPage(keys) {
+				authorIDs := pageIDs(keys, idxs)
+				// db query
+				res, err := repo.ListBooksByAuthorIDsPage(ctx, page.BooksByAuthorIDsParams(authorIDs))
+				if err != nil {
+					return nil, []error{err}
+				}
+				counts, err := repo.CountBooksByAuthorIDs(ctx, authorIDs)
+				if err != nil {
+					return nil, []error{err}
+				}
+				// group
+				groupByAuthorID := make(map[int64][]pg.Book, len(authorIDs))
+				for _, r := range res {
+					book := pg.Book{
+						ID:          r.ID,
+						Title:       r.Title,
+						Description: r.Description,
+						Cover:       r.Cover,
+						Version:     r.Version,
+					}
+					groupByAuthorID[r.AuthorID] = append(groupByAuthorID[r.AuthorID], book)
+					bookByID.Prime(book.ID, &book)
+				}
+				countByAuthorID := make(map[int64]int64, len(authorIDs))
+				for _, c := range counts {
+					countByAuthorID[c.AuthorID] = c.Count
+				}
+				// order
+				for _, i := range idxs {
+					authorID := keys[i].ID
+					result[i] = pg.NewBookConnection(page, groupByAuthorID[authorID], countByAuthorID[authorID])
+				}
+			}
+			fetched.add(keys)
+			return result, nil
+		}).(func([]PageKey) ([]*pg.BookConnection, []error)),
+	})
+}
+
+// newHistoryByEntityID returns a loader of the audit entries of the entities
+// of one kind.
+func newHistoryByEntityID(ctx context.Context, repo pg.Repository, cfg loaderConfig, entity string, fetched *pageKeys) *AuditEntryConnectionLoader {
+	return NewAuditEntryConnectionLoader(AuditEntryConnectionLoaderConfig{
+		MaxBatch: cfg.batchMax(),
+		Wait:     cfg.batchWait(),
+		Fetch: cfg.merge(func(keys []PageKey) (_ []*pg.AuditEntryConnection, errs []error) {
+			ctx, done := cfg.startBatch(ctx, len(keys))
+			defer func() { done(errs) }()
+			result := make([]*pg.AuditEntryConnection, len(keys))
+			for page, idxs := range groupByPage(keys) {
+				entityIDs := pageIDs(keys, idxs)
+				// db query
+				res, err := repo.ListAuditEntriesByEntityIDsPage(ctx, page.AuditEntriesByEntityIDsParams(entity, entityIDs))
+				if err != nil {
+					return nil, []error{err}
+				}
+				// group
+				groupByEntityID := make(map[int64][]pg.AuditEntry, len(entityIDs))
+				for _, r := range res {
+					groupByEntityID[r.EntityID] = append(groupByEntityID[r.EntityID], r)
+				}
+				// order
+				for _, i := range idxs {
+					result[i] = pg.NewAuditEntryConnection(page, groupByEntityID[keys[i].ID])
+				}
+			}
+			fetched.add(keys)
+			return result, nil
+		}).(func([]PageKey) ([]*pg.AuditEntryConnection, []error)),
 	})
 }
+
+// groupByPage groups the positions of keys by the requested page, so that one
+// query is issued for all parents requesting the same page.
+func groupByPage(keys []PageKey) map[pg.Page][]int {
+	groups := make(map[pg.Page][]int)
+	for i, key := range keys {
+		groups[key.Page] = append(groups[key.Page], i)
+	}
+	return groups
+}
+
+// pageIDs returns the parent ids of the keys at the given positions.
+func pageIDs(keys []PageKey, idxs []int) []int64 {
+	ids := make([]int64, len(idxs))
+	for i, idx := range idxs {
+		ids[i] = keys[idx].ID
+	}
+	return ids
+}
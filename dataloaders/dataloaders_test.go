@@ -0,0 +1,258 @@
+package dataloaders
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/memory" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"     // update the username
+)
+
+// batchRecorder records the sizes of the batches fetched by each loader.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches map[string][]int
+}
+
+func (r *batchRecorder) StartBatch(ctx context.Context, loader string, keys int) (context.Context, func(error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.batches == nil {
+		r.batches = make(map[string][]int)
+	}
+	r.batches[loader] = append(r.batches[loader], keys)
+	return ctx, func(error) {}
+}
+
+// sizes returns the sorted sizes of the batches of loader.
+func (r *batchRecorder) sizes(loader string) []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sizes := append([]int{}, r.batches[loader]...)
+	sort.Ints(sizes)
+	return sizes
+}
+
+// newTestLoaders returns loaders configured by cfg, recording their batches,
+// over a repository holding n agents with an author each. The adaptive
+// loaders are dispatched by d.
+func newTestLoaders(t *testing.T, cfg Config, d *dispatcher, n int) (*Loaders, *batchRecorder, []int64) {
+	t.Helper()
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	var ids []int64
+	for i := 0; i < n; i++ {
+		agent, err := repo.CreateAgent(ctx, pg.CreateAgentParams{Name: "agent", Email: "agent@example.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.CreateAuthor(ctx, pg.CreateAuthorParams{Name: "author", AgentID: agent.ID}); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, agent.ID)
+	}
+	rec := &batchRecorder{}
+	cfg.Observer = rec
+	return newLoaders(ctx, repo, cfg, d), rec, ids
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func equalSizes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBatchMerging(t *testing.T) {
+	l, rec, ids := newTestLoaders(t, Config{Wait: 50 * time.Millisecond}, nil, 5)
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			agent, err := l.AgentByID.Load(id)
+			if err != nil || agent == nil || agent.ID != id {
+				t.Errorf("AgentByID.Load(%d) = %v, %v", id, agent, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+	if got := rec.sizes("AgentByID"); !equalSizes(got, []int{5}) {
+		t.Errorf("AgentByID batches = %v, want [5]", got)
+	}
+}
+
+func TestMaxBatch(t *testing.T) {
+	two := 2
+	cfg := Config{
+		Wait:    time.Millisecond,
+		Loaders: map[string]LoaderConfig{"AgentByID": {MaxBatch: &two}},
+	}
+	l, rec, ids := newTestLoaders(t, cfg, nil, 5)
+	if _, errs := l.AgentByID.LoadAll(ids); firstError(errs) != nil {
+		t.Fatal(firstError(errs))
+	}
+	if got := rec.sizes("AgentByID"); !equalSizes(got, []int{1, 2, 2}) {
+		t.Errorf("AgentByID batches = %v, want [1 2 2]", got)
+	}
+	// the other loaders keep the unlimited batches of Config, the authors
+	// were created with the IDs of their agents
+	if _, errs := l.AuthorByID.LoadAll(ids); firstError(errs) != nil {
+		t.Fatal(firstError(errs))
+	}
+	if got := rec.sizes("AuthorByID"); !equalSizes(got, []int{5}) {
+		t.Errorf("AuthorByID batches = %v, want [5]", got)
+	}
+}
+
+func TestWaitUpperBound(t *testing.T) {
+	wait := 10 * time.Millisecond
+	cfg := Config{
+		Wait:    time.Hour,
+		Loaders: map[string]LoaderConfig{"AgentByAuthorID": {Wait: &wait}},
+	}
+	l, rec, ids := newTestLoaders(t, cfg, nil, 1)
+	start := time.Now()
+	agent, err := l.AgentByAuthorID.Load(ids[0])
+	if err != nil || agent == nil {
+		t.Fatalf("AgentByAuthorID.Load = %v, %v", agent, err)
+	}
+	// a batch which does not fill up is fetched once the wait of its loader
+	// has passed
+	if elapsed := time.Since(start); elapsed < wait || elapsed > time.Second {
+		t.Errorf("AgentByAuthorID.Load took %v, want between %v and 1s", elapsed, wait)
+	}
+	if got := rec.sizes("AgentByAuthorID"); !equalSizes(got, []int{1}) {
+		t.Errorf("AgentByAuthorID batches = %v, want [1]", got)
+	}
+}
+
+// resolve runs fn as a resolver tracked by the adaptive mode.
+func resolve(ctx context.Context, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return tracker{}.track(ctx, fn)
+}
+
+func TestAdaptiveDispatch(t *testing.T) {
+	d := newDispatcher()
+	cfg := Config{Wait: time.Hour, Adaptive: true}
+	l, rec, ids := newTestLoaders(t, cfg, d, 2)
+	ctx := context.WithValue(context.Background(), key, &scope{loaders: l, dispatcher: d})
+
+	// a running resolver which does not load holds the batch
+	started, release := make(chan struct{}), make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		resolve(ctx, func(context.Context) (interface{}, error) {
+			close(started)
+			<-release
+			return nil, nil
+		})
+	}()
+	<-started
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			agent, err := resolve(ctx, func(ctx context.Context) (interface{}, error) {
+				return NewRetriever().Retrieve(ctx).AgentByID.Load(id)
+			})
+			if err != nil || agent.(*pg.Agent).ID != id {
+				t.Errorf("AgentByID.Load(%d) = %v, %v", id, agent, err)
+			}
+		}(id)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := rec.sizes("AgentByID"); len(got) != 0 {
+		t.Fatalf("AgentByID batches = %v before the running resolver returned, want none", got)
+	}
+
+	// the batch is fetched as soon as the resolvers all load, long before
+	// the wait has passed
+	close(release)
+	wg.Wait()
+	if got := rec.sizes("AgentByID"); !equalSizes(got, []int{2}) {
+		t.Errorf("AgentByID batches = %v, want [2]", got)
+	}
+
+	// the fields announced by a resolver hold the batch until they all load
+	d.announce(2)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := resolve(ctx, func(ctx context.Context) (interface{}, error) {
+			return NewRetriever().Retrieve(ctx).AuthorByID.Load(ids[0])
+		}); err != nil {
+			t.Error(err)
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if got := rec.sizes("AuthorByID"); len(got) != 0 {
+		t.Fatalf("AuthorByID batches = %v before the announced field loaded, want none", got)
+	}
+	if _, err := resolve(ctx, func(ctx context.Context) (interface{}, error) {
+		return NewRetriever().Retrieve(ctx).AuthorByID.Load(ids[1])
+	}); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+	if got := rec.sizes("AuthorByID"); !equalSizes(got, []int{2}) {
+		t.Errorf("AuthorByID batches = %v, want [2]", got)
+	}
+}
+
+func TestForSubscription(t *testing.T) {
+	repo := memory.NewRepository()
+	agent, err := repo.CreateAgent(context.Background(), pg.CreateAgentParams{Name: "agent", Email: "agent@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ctx context.Context
+	h := Middleware(repo, Config{Wait: time.Millisecond, Adaptive: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	r := NewRetriever()
+	loaders := r.Retrieve(ctx)
+
+	sub := ForSubscription(ctx)
+	subLoaders := r.Retrieve(sub)
+	if subLoaders == loaders {
+		t.Fatal("the subscription shares the loaders of the request")
+	}
+	// the events of the subscription are not announced to a dispatcher
+	if dispatcherFor(ctx) == nil || dispatcherFor(sub) != nil {
+		t.Errorf("dispatchers %p and %p, want one for the request only", dispatcherFor(ctx), dispatcherFor(sub))
+	}
+	if got, err := subLoaders.AgentByID.Load(agent.ID); err != nil || got == nil || got.ID != agent.ID {
+		t.Errorf("AgentByID = %v, %v, want agent %d", got, err, agent.ID)
+	}
+
+	Reset(sub)
+	if r.Retrieve(sub) == subLoaders {
+		t.Error("the loaders of the subscription were not reset")
+	}
+	if r.Retrieve(ctx) != loaders {
+		t.Error("resetting the subscription reset the loaders of the request")
+	}
+}
@@ -8,12 +8,18 @@ import (
 )
 
 // Middleware stores Loaders as a request-scoped context value.
-func Middleware(repo pg.Repository) func(http.Handler) http.Handler {
+func Middleware(repo pg.Repository, cfg Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
-			loaders := newLoaders(ctx, repo)
-			augmentedCtx := context.WithValue(ctx, key, loaders)
+			var d *dispatcher
+			if cfg.Adaptive {
+				d = newDispatcher()
+			}
+			s := newScope(ctx, func(ctx context.Context, d *dispatcher) *Loaders {
+				return newLoaders(ctx, repo, cfg, d)
+			}, d)
+			augmentedCtx := context.WithValue(ctx, key, s)
 			r = r.WithContext(augmentedCtx)
 			next.ServeHTTP(w, r)
 		})
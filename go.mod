@@ -1,9 +1,19 @@
 module github.com/fwojciec/gqlgen-sqlc-example
 
-go 1.13
+go 1.16
 
 require (
 	github.com/99designs/gqlgen v0.10.2
+	github.com/BurntSushi/toml v1.2.0
+	github.com/golang-jwt/jwt/v4 v4.5.2
+	github.com/gorilla/websocket v1.2.0
 	github.com/lib/pq v1.3.0
+	github.com/prometheus/client_golang v1.12.2
 	github.com/vektah/gqlparser v1.2.0
+	go.opentelemetry.io/otel v1.7.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.7.0
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.7.0
+	go.opentelemetry.io/otel/sdk v1.7.0
+	go.opentelemetry.io/otel/trace v1.7.0
+	gopkg.in/yaml.v2 v2.4.0
 )
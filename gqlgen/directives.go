@@ -0,0 +1,52 @@
+package gqlgen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fwojciec/gqlgen-sqlc-example/auth" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"   // update the username
+)
+
+// hasRole implements the @hasRole directive. Fields the principal may not
+// access resolve to null with a FORBIDDEN error, leaving the rest of the
+// response intact.
+func hasRole(ctx context.Context, obj interface{}, next graphql.Resolver, role Role, allowSelf *bool) (interface{}, error) {
+	p := auth.ForContext(ctx)
+	if p.HasRole(auth.Role(role)) {
+		return next(ctx)
+	}
+	if allowSelf != nil && *allowSelf && isSelf(p, obj) {
+		return next(ctx)
+	}
+	return nil, fmt.Errorf("%w: %s requires role %s", auth.ErrForbidden, fieldName(ctx), role)
+}
+
+// isSelf reports whether obj is the agent the principal acts as.
+func isSelf(p *auth.Principal, obj interface{}) bool {
+	if p.AgentID == nil {
+		return false
+	}
+	switch a := obj.(type) {
+	case *pg.Agent:
+		return a != nil && a.ID == *p.AgentID
+	case pg.Agent:
+		return a.ID == *p.AgentID
+	}
+	return false
+}
+
+func fieldName(ctx context.Context) string {
+	rctx := graphql.GetResolverContext(ctx)
+	return rctx.Object + "." + rctx.Field.Name
+}
+
+// checkIncludeDeleted restricts the includeDeleted argument of the queries
+// to admins.
+func checkIncludeDeleted(ctx context.Context, includeDeleted bool) error {
+	if includeDeleted && !auth.ForContext(ctx).HasRole(auth.RoleAdmin) {
+		return fmt.Errorf("%w: includeDeleted of %s requires role %s", auth.ErrForbidden, fieldName(ctx), RoleAdmin)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package gqlgen_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/auth"        // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen"      // update the username
+)
+
+var (
+	editor = &auth.Principal{Subject: "editor", Roles: []auth.Role{auth.RoleEditor}}
+	// self acts as the agent of newHandler, other as another one
+	self  = &auth.Principal{Subject: "self", AgentID: int64Ptr(1)}
+	other = &auth.Principal{Subject: "other", AgentID: int64Ptr(2)}
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// forbidden returns the paths of the FORBIDDEN errors of errs, failing on
+// the other errors.
+func forbidden(t *testing.T, errs []responseError) []string {
+	t.Helper()
+	var paths []string
+	for _, err := range errs {
+		if err.Extensions.Code != gqlgen.CodeForbidden {
+			t.Fatalf("error %+v, want %s", err, gqlgen.CodeForbidden)
+		}
+		paths = append(paths, fmt.Sprint(err.Path))
+	}
+	return paths
+}
+
+// equalJSON reports whether the JSON documents a and b are equal, ignoring
+// the whitespace.
+func equalJSON(t *testing.T, a []byte, b string) bool {
+	t.Helper()
+	var ca, cb bytes.Buffer
+	if err := json.Compact(&ca, a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Compact(&cb, []byte(b)); err != nil {
+		t.Fatal(err)
+	}
+	return ca.String() == cb.String()
+}
+
+func TestHasRole(t *testing.T) {
+	createAgent := `mutation {
+		createAgent(data: {name: "New", email: "new@example.com"}) { name }
+		createAuthor(data: {name: "New", agent_id: "` + agent1 + `"}) { name }
+	}`
+	email := `{ agent(id: "` + agent1 + `") { name email } }`
+	tests := []struct {
+		name          string
+		principal     *auth.Principal
+		query         string
+		wantData      string
+		wantForbidden []string
+	}{
+		{
+			name:      "allowed",
+			principal: admin,
+			query:     createAgent,
+			wantData:  `{"createAgent": {"name": "New"}, "createAuthor": {"name": "New"}}`,
+		},
+		{
+			// the denied mutation is null, the others are still executed
+			name:          "denied",
+			principal:     editor,
+			query:         createAgent,
+			wantData:      `{"createAgent": null, "createAuthor": {"name": "New"}}`,
+			wantForbidden: []string{"[createAgent]"},
+		},
+		{
+			name:          "anonymous",
+			principal:     auth.Anonymous,
+			query:         createAgent,
+			wantData:      `{"createAgent": null, "createAuthor": null}`,
+			wantForbidden: []string{"[createAgent]", "[createAuthor]"},
+		},
+		{
+			name:      "role of allowSelf field",
+			principal: admin,
+			query:     email,
+			wantData:  `{"agent": {"name": "Agent", "email": "agent@example.com"}}`,
+		},
+		{
+			name:      "self",
+			principal: self,
+			query:     email,
+			wantData:  `{"agent": {"name": "Agent", "email": "agent@example.com"}}`,
+		},
+		{
+			name:          "not self",
+			principal:     other,
+			query:         email,
+			wantData:      `{"agent": {"name": "Agent", "email": null}}`,
+			wantForbidden: []string{"[agent email]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := as(tt.principal, newLoadingHandler(t, dataloaders.Config{Wait: time.Millisecond}))
+			var data json.RawMessage
+			paths := forbidden(t, execute(t, h, tt.query, &data))
+			if !equalJSON(t, data, tt.wantData) {
+				t.Errorf("data = %s, want %s", data, tt.wantData)
+			}
+			if !equal(paths, tt.wantForbidden) {
+				t.Errorf("forbidden paths = %q, want %q", paths, tt.wantForbidden)
+			}
+		})
+	}
+}
+
+func TestIncludeDeleted(t *testing.T) {
+	tests := []struct {
+		name           string
+		principal      *auth.Principal
+		includeDeleted bool
+		wantData       string
+		wantForbidden  []string
+	}{
+		{"admin", admin, true, `{"book": {"title": "Book"}}`, nil},
+		{"editor", editor, true, `{"book": null}`, []string{"[book]"}},
+		{"anonymous", auth.Anonymous, true, `{"book": null}`, []string{"[book]"}},
+		// the deleted entities are hidden from everyone by default
+		{"admin without includeDeleted", admin, false, `{"book": null}`, nil},
+		{"editor without includeDeleted", editor, false, `{"book": null}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newLoadingHandler(t, dataloaders.Config{Wait: time.Millisecond})
+			var deleted struct{ DeleteBook struct{ Title string } }
+			do(t, as(admin, h), `mutation { deleteBook(id: "`+book1+`") { title } }`, &deleted)
+
+			var data json.RawMessage
+			query := fmt.Sprintf(`{ book(id: "%s", includeDeleted: %t) { title } }`, book1, tt.includeDeleted)
+			paths := forbidden(t, execute(t, as(tt.principal, h), query, &data))
+			if !equalJSON(t, data, tt.wantData) {
+				t.Errorf("data = %s, want %s", data, tt.wantData)
+			}
+			if !equal(paths, tt.wantForbidden) {
+				t.Errorf("forbidden paths = %q, want %q", paths, tt.wantForbidden)
+			}
+		})
+	}
+}
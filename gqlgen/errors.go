@@ -0,0 +1,55 @@
+package gqlgen
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fwojciec/gqlgen-sqlc-example/auth" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"   // update the username
+	"github.com/vektah/gqlparser/gqlerror"
+)
+
+// Error codes reported in the extensions of GraphQL errors.
+const (
+	CodeNotFound         = "NOT_FOUND"
+	CodeConflict         = "CONFLICT"
+	CodeInvalidReference = "INVALID_REFERENCE"
+	CodeInvalidInput     = "INVALID_INPUT"
+	CodeForbidden        = "FORBIDDEN"
+)
+
+// errorCodes maps the errors of the repository and of authorization to
+// error codes.
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{pg.ErrNotFound, CodeNotFound},
+	{pg.ErrConflict, CodeConflict},
+	{pg.ErrInvalidReference, CodeInvalidReference},
+	{pg.ErrInvalidInput, CodeInvalidInput},
+	{ErrInvalidID, CodeInvalidInput},
+	{auth.ErrForbidden, CodeForbidden},
+}
+
+// presentError adds the error code of known errors to the extensions
+// of the GraphQL error, and the current version of the entity to those of
+// version conflicts.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			if gqlErr.Extensions == nil {
+				gqlErr.Extensions = make(map[string]interface{})
+			}
+			gqlErr.Extensions["code"] = c.code
+			break
+		}
+	}
+	var vErr *pg.VersionError
+	if errors.As(err, &vErr) {
+		gqlErr.Extensions["currentVersion"] = vErr.Current
+	}
+	return gqlErr
+}
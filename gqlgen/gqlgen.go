@@ -1,24 +1,66 @@
 package gqlgen
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/handler"
+	"github.com/fwojciec/gqlgen-sqlc-example/auth"        // update the username
 	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
 	"github.com/fwojciec/gqlgen-sqlc-example/pg"          // update the username
+	"github.com/vektah/gqlparser/ast"
 )
 
-// NewHandler returns a new graphql endpoint handler.
-func NewHandler(repo pg.Repository, dl dataloaders.Retriever) http.Handler {
-	return handler.GraphQL(NewExecutableSchema(Config{
+// NewHandler returns a new graphql endpoint handler rejecting operations
+// which exceed limits. Subscriptions are served over WebSocket with events
+// delivered by broker. The options are applied after the defaults.
+func NewHandler(repo pg.Repository, dl dataloaders.Retriever, broker Broker, limits Limits, options ...handler.Option) http.Handler {
+	es := subscriptionSchema{NewExecutableSchema(Config{
 		Resolvers: &Resolver{
 			Repository:  repo,
 			DataLoaders: dl,
+			Broker:      broker,
 		},
-	}))
+		Directives: DirectiveRoot{
+			HasRole: hasRole,
+		},
+		Complexity: complexityRoot(),
+	})}
+	options = append([]handler.Option{
+		handler.ErrorPresenter(presentError),
+		handler.RequestMiddleware(recordActor),
+	}, options...)
+	// the limits are checked innermost so that rejected operations are still
+	// logged and measured by the other middlewares
+	options = append(options, handler.RequestMiddleware(limits.enforce(es)))
+	return handler.GraphQL(es, options...)
+}
+
+// Schema returns the schema served by the handler.
+func Schema() *ast.Schema {
+	return parsedSchema
+}
+
+// recordActor makes the repository record the subject of the principal as
+// the actor of the changes made by the operation.
+func recordActor(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+	return next(pg.WithActor(ctx, auth.ForContext(ctx).Subject))
 }
 
 // NewPlaygroundHandler returns a new GraphQL Playground handler.
 func NewPlaygroundHandler(endpoint string) http.Handler {
 	return handler.Playground("GraphQL Playground", endpoint)
 }
+
+// OperationName returns the name of the operation executed by the request,
+// or "anonymous" if it has none.
+func OperationName(rctx *graphql.RequestContext) string {
+	if rctx.OperationName != "" {
+		return rctx.OperationName
+	}
+	if rctx.Doc != nil && len(rctx.Doc.Operations) == 1 && rctx.Doc.Operations[0].Name != "" {
+		return rctx.Doc.Operations[0].Name
+	}
+	return "anonymous"
+}
@@ -0,0 +1,327 @@
+package gqlgen_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/auth"        // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/memory"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"          // update the username
+)
+
+// The global IDs of the entities created first.
+const (
+	agent1  = "QWdlbnQ6MQ"
+	author1 = "QXV0aG9yOjE"
+	author2 = "QXV0aG9yOjI"
+	book1   = "Qm9vazox"
+)
+
+// admin is the principal of the requests of newHandler.
+var admin = &auth.Principal{Subject: "admin", Roles: []auth.Role{auth.RoleAdmin}}
+
+// newHandler returns the query handler wired as in the server, backed by an
+// in-memory repository holding an agent, two authors and a book of the
+// first author. The requests are made by an admin.
+func newHandler(t *testing.T) http.Handler {
+	t.Helper()
+	return as(admin, newLoadingHandler(t, dataloaders.Config{Wait: time.Millisecond}))
+}
+
+// newLoadingHandler returns the handler of newHandler with the loaders
+// configured by cfg, for anonymous requests.
+func newLoadingHandler(t *testing.T, cfg dataloaders.Config) http.Handler {
+	t.Helper()
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	agent, err := repo.CreateAgent(ctx, pg.CreateAgentParams{Name: "Agent", Email: "agent@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var authorIDs []int64
+	for _, name := range []string{"Author 1", "Author 2"} {
+		author, err := repo.CreateAuthor(ctx, pg.CreateAuthorParams{Name: name, AgentID: agent.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		authorIDs = append(authorIDs, author.ID)
+	}
+	if _, err := repo.CreateBook(ctx, pg.CreateBookParams{Title: "Book", Description: "", Cover: ""}, authorIDs[:1]); err != nil {
+		t.Fatal(err)
+	}
+	h := gqlgen.NewHandler(repo, dataloaders.NewRetriever(), memory.NewBroker(), gqlgen.Limits{}, cfg.HandlerOptions(gqlgen.Schema())...)
+	return dataloaders.Middleware(repo, cfg)(h)
+}
+
+// as makes the requests to h on behalf of p.
+func as(p *auth.Principal, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), p)))
+	})
+}
+
+// responseError is an error of a response.
+type responseError struct {
+	Message    string
+	Path       []interface{}
+	Extensions struct{ Code string }
+}
+
+// execute executes query, decodes the data of the response into data and
+// returns the errors of the response.
+func execute(t *testing.T, h http.Handler, query string, data interface{}) []responseError {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"query": query})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(w, r)
+	var res struct {
+		Data   json.RawMessage
+		Errors []responseError
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body, err)
+	}
+	if err := json.Unmarshal(res.Data, data); err != nil {
+		t.Fatalf("decoding %s: %v", res.Data, err)
+	}
+	return res.Errors
+}
+
+// do executes query and decodes the data of the response into data, failing
+// on errors.
+func do(t *testing.T, h http.Handler, query string, data interface{}) {
+	t.Helper()
+	if errs := execute(t, h, query, data); len(errs) > 0 {
+		t.Fatalf("errors: %+v", errs)
+	}
+}
+
+type nameConnection struct {
+	Edges []struct {
+		Node struct {
+			ID    string
+			Name  string
+			Title string
+			Books *nameConnection
+		}
+	}
+}
+
+// names returns the names or titles of the nodes of c.
+func (c nameConnection) names() []string {
+	var names []string
+	for _, e := range c.Edges {
+		names = append(names, e.Node.Name+e.Node.Title)
+	}
+	return names
+}
+
+// firstBooks returns the books of the first node of c.
+func (c nameConnection) firstBooks() nameConnection {
+	if len(c.Edges) == 0 || c.Edges[0].Node.Books == nil {
+		return nameConnection{}
+	}
+	return *c.Edges[0].Node.Books
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Every mutation of a document reads the changes of the previous ones,
+// although the nested fields of the previous ones loaded the same entities.
+func TestMutationsReadPreviousMutations(t *testing.T) {
+	h := newHandler(t)
+	var data struct {
+		First struct {
+			Title   string
+			Authors nameConnection
+		}
+		Author struct {
+			Name  string
+			Books nameConnection
+		}
+		Second struct {
+			Title   string
+			Version int
+			Authors nameConnection
+		}
+	}
+	do(t, h, `mutation {
+		first: updateBook(id: "`+book1+`", data: {title: "First", description: "", cover: "", authorIDs: ["`+author1+`"]}) {
+			title authors { edges { node { name books { edges { node { title } } } } } }
+		}
+		author: updateAuthor(id: "`+author1+`", data: {name: "Renamed", agent_id: "`+agent1+`"}) {
+			name books { edges { node { title } } }
+		}
+		second: updateBook(id: "`+book1+`", data: {title: "Second", description: "", cover: "", authorIDs: ["`+author1+`"]}) {
+			title version authors { edges { node { name books { edges { node { title } } } } } }
+		}
+	}`, &data)
+
+	if got := data.First.Authors.firstBooks().names(); !equal(got, []string{"First"}) {
+		t.Errorf("first: author books = %q, want [First]", got)
+	}
+	if got := data.Author.Books.names(); !equal(got, []string{"First"}) {
+		t.Errorf("author: books = %q, want [First]", got)
+	}
+	if data.Second.Title != "Second" || data.Second.Version != 3 {
+		t.Errorf("second: title %q version %d, want Second version 3", data.Second.Title, data.Second.Version)
+	}
+	if got := data.Second.Authors.names(); !equal(got, []string{"Renamed"}) {
+		t.Errorf("second: authors = %q, want [Renamed]", got)
+	}
+	if got := data.Second.Authors.firstBooks().names(); !equal(got, []string{"Second"}) {
+		t.Errorf("second: author books = %q, want [Second]", got)
+	}
+}
+
+// Changing the authors of a book updates both sides of the association for
+// the nested reads of the same document.
+func TestUpdateBookAuthorsReadBack(t *testing.T) {
+	h := newHandler(t)
+	var data struct {
+		Before struct {
+			Books nameConnection
+		}
+		Primed struct {
+			Books nameConnection
+		}
+		Update struct {
+			Authors nameConnection
+		}
+		Previous struct {
+			Books nameConnection
+		}
+	}
+	do(t, h, `mutation {
+		before: updateAuthor(id: "`+author2+`", data: {name: "Author 2", agent_id: "`+agent1+`"}) {
+			books { edges { node { title } } }
+		}
+		primed: updateAuthor(id: "`+author1+`", data: {name: "Author 1", agent_id: "`+agent1+`"}) {
+			books { edges { node { title } } }
+		}
+		update: updateBook(id: "`+book1+`", data: {title: "Book", description: "", cover: "", authorIDs: ["`+author2+`"]}) {
+			authors { edges { node { name books { edges { node { title } } } } } }
+		}
+		previous: updateAuthor(id: "`+author1+`", data: {name: "Author 1", agent_id: "`+agent1+`"}) {
+			books { edges { node { title } } }
+		}
+	}`, &data)
+
+	if got := data.Before.Books.names(); len(got) != 0 {
+		t.Errorf("before: books = %q, want none", got)
+	}
+	if got := data.Primed.Books.names(); !equal(got, []string{"Book"}) {
+		t.Errorf("primed: books = %q, want [Book]", got)
+	}
+	if got := data.Update.Authors.names(); !equal(got, []string{"Author 2"}) {
+		t.Errorf("update: authors = %q, want [Author 2]", got)
+	}
+	if got := data.Update.Authors.firstBooks().names(); !equal(got, []string{"Book"}) {
+		t.Errorf("update: author books = %q, want [Book]", got)
+	}
+	if got := data.Previous.Books.names(); len(got) != 0 {
+		t.Errorf("previous: books = %q, want none", got)
+	}
+}
+
+// batchRecorder records the sizes of the batches fetched by each loader.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches map[string][]int
+}
+
+func (r *batchRecorder) StartBatch(ctx context.Context, loader string, keys int) (context.Context, func(error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.batches == nil {
+		r.batches = make(map[string][]int)
+	}
+	r.batches[loader] = append(r.batches[loader], keys)
+	return ctx, func(error) {}
+}
+
+// The adaptive loaders fetch the keys of the resolvers of each level in one
+// batch, as soon as they all load.
+func TestAdaptiveBatches(t *testing.T) {
+	rec := &batchRecorder{}
+	h := as(admin, newLoadingHandler(t, dataloaders.Config{Wait: time.Minute, Adaptive: true, Observer: rec}))
+	var data struct {
+		Authors nameConnection
+		Nodes   []struct{ Name, Title string }
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		do(t, h, `{
+			authors(first: 10) { edges { node { ...author agent { name } } } }
+			nodes(ids: ["`+author2+`", "`+book1+`"]) { __typename ... on Author { name } ... on Book { title } }
+		}
+		fragment author on Author {
+			name
+			books(first: 10) { edges { node { title authors(first: 10) { edges { node { __typename name } } } } } }
+		}`, &data)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the batches waited for Wait")
+	}
+
+	if got := data.Authors.names(); !equal(got, []string{"Author 1", "Author 2"}) {
+		t.Errorf("authors = %q, want [Author 1 Author 2]", got)
+	}
+	if got := data.Authors.firstBooks().names(); !equal(got, []string{"Book"}) {
+		t.Errorf("author books = %q, want [Book]", got)
+	}
+	if len(data.Nodes) != 2 || data.Nodes[0].Name != "Author 2" || data.Nodes[1].Title != "Book" {
+		t.Errorf("nodes = %+v, want Author 2 and Book", data.Nodes)
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for _, loader := range []string{"AgentByAuthorID", "BooksByAuthorID"} {
+		if got := rec.batches[loader]; len(got) != 1 || got[0] != 2 {
+			t.Errorf("%s batches = %v, want [2]", loader, got)
+		}
+	}
+}
+
+// A cursor cannot be used with another order than the one it was returned
+// for.
+func TestCursorOrder(t *testing.T) {
+	h := newHandler(t)
+	var data struct {
+		Authors struct {
+			PageInfo struct{ EndCursor string }
+		}
+	}
+	do(t, h, `{ authors(orderBy: NAME_DESC, first: 1) { pageInfo { endCursor } } }`, &data)
+	var next struct{ Authors *nameConnection }
+	do(t, h, `{ authors(orderBy: NAME_DESC, after: "`+data.Authors.PageInfo.EndCursor+`") { edges { node { name } } } }`, &next)
+	if got := next.Authors.names(); !equal(got, []string{"Author 1"}) {
+		t.Errorf("next page = %q, want [Author 1]", got)
+	}
+	errs := execute(t, h, `{ authors(orderBy: NAME_ASC, after: "`+data.Authors.PageInfo.EndCursor+`") { edges { node { name } } } }`, &next)
+	if len(errs) != 1 || errs[0].Extensions.Code != gqlgen.CodeInvalidInput {
+		t.Errorf("errors %+v, want %s", errs, gqlgen.CodeInvalidInput)
+	}
+}
@@ -0,0 +1,143 @@
+package gqlgen
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+// The IDs of the schema are global: they encode the type of the object
+// together with its database id, so that objects of different types never
+// share an ID. They are opaque to the clients.
+
+// Types encoded in the global IDs.
+const (
+	typeAgent      = "Agent"
+	typeAuthor     = "Author"
+	typeBook       = "Book"
+	typeAuditEntry = "AuditEntry"
+)
+
+// entityTypes maps the entities of the repository to their types.
+var entityTypes = map[string]string{
+	pg.EntityAgent:  typeAgent,
+	pg.EntityAuthor: typeAuthor,
+	pg.EntityBook:   typeBook,
+}
+
+// ErrInvalidID is returned for IDs which cannot be decoded or which identify
+// an object of another type than expected.
+var ErrInvalidID = errors.New("invalid id")
+
+// globalID returns the global ID of the object of type typ with the given
+// database id.
+func globalID(typ string, id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(typ + ":" + strconv.FormatInt(id, 10)))
+}
+
+// parseGlobalID returns the type and the database id encoded in a global ID.
+func parseGlobalID(s string) (string, int64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return "", 0, ErrInvalidID
+	}
+	parts := strings.SplitN(string(b), ":", 2)
+	if len(parts) != 2 {
+		return "", 0, ErrInvalidID
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, ErrInvalidID
+	}
+	return parts[0], id, nil
+}
+
+// decodeID returns the database id of the object of type typ identified by
+// the global ID s.
+func decodeID(typ, s string) (int64, error) {
+	t, id, err := parseGlobalID(s)
+	if err != nil {
+		return 0, err
+	}
+	if t != typ {
+		return 0, ErrInvalidID
+	}
+	return id, nil
+}
+
+// decodeIDs is like decodeID for lists of IDs.
+func decodeIDs(typ string, ss []string) ([]int64, error) {
+	if ss == nil {
+		return nil, nil
+	}
+	ids := make([]int64, len(ss))
+	for i, s := range ss {
+		id, err := decodeID(typ, s)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// decodeOptionalID is like decodeID for optional IDs.
+func decodeOptionalID(typ string, s *string) (*int64, error) {
+	if s == nil {
+		return nil, nil
+	}
+	id, err := decodeID(typ, *s)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// AuthorFilter is the input of pg.AuthorFilter, the IDs of which need to be
+// decoded.
+type AuthorFilter struct {
+	NameContains *string  `json:"nameContains"`
+	HasWebsite   *bool    `json:"hasWebsite"`
+	AgentIDs     []string `json:"agentIDs"`
+}
+
+func (f *AuthorFilter) decode() (pg.AuthorFilter, error) {
+	if f == nil {
+		return pg.AuthorFilter{}, nil
+	}
+	agentIDs, err := decodeIDs(typeAgent, f.AgentIDs)
+	if err != nil {
+		return pg.AuthorFilter{}, err
+	}
+	return pg.AuthorFilter{
+		NameContains: f.NameContains,
+		HasWebsite:   f.HasWebsite,
+		AgentIDs:     agentIDs,
+	}, nil
+}
+
+// BookFilter is the input of pg.BookFilter, the IDs of which need to be
+// decoded.
+type BookFilter struct {
+	TitleContains       *string  `json:"titleContains"`
+	DescriptionContains *string  `json:"descriptionContains"`
+	AuthorIDs           []string `json:"authorIDs"`
+}
+
+func (f *BookFilter) decode() (pg.BookFilter, error) {
+	if f == nil {
+		return pg.BookFilter{}, nil
+	}
+	authorIDs, err := decodeIDs(typeAuthor, f.AuthorIDs)
+	if err != nil {
+		return pg.BookFilter{}, err
+	}
+	return pg.BookFilter{
+		TitleContains:       f.TitleContains,
+		DescriptionContains: f.DescriptionContains,
+		AuthorIDs:           authorIDs,
+	}, nil
+}
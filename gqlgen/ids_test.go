@@ -0,0 +1,74 @@
+package gqlgen_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen" // update the username
+)
+
+func encodeID(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func TestGlobalIDs(t *testing.T) {
+	h := newHandler(t)
+	var data struct {
+		Agent, Author, Book struct{ ID string }
+		Nodes               []struct{ ID string }
+	}
+	do(t, h, `{
+		agent: node(id: "`+agent1+`") { id }
+		author: node(id: "`+author1+`") { id }
+		book: node(id: "`+book1+`") { id }
+		nodes(ids: ["`+book1+`", "`+agent1+`", "`+author2+`"]) { id }
+	}`, &data)
+	if data.Agent.ID != agent1 || data.Author.ID != author1 || data.Book.ID != book1 {
+		t.Errorf("nodes %+v, want %s, %s and %s", data, agent1, author1, book1)
+	}
+	var ids []string
+	for _, node := range data.Nodes {
+		ids = append(ids, node.ID)
+	}
+	if want := []string{book1, agent1, author2}; !equal(ids, want) {
+		t.Errorf("nodes = %q, want %q", ids, want)
+	}
+	// the IDs encode the type and the database id
+	if want := encodeID("Agent:1"); agent1 != want {
+		t.Errorf("agent ID = %s, want %s", agent1, want)
+	}
+}
+
+func TestInvalidIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"malformed base64", `{ node(id: "!!!") { id } }`},
+		{"padded base64", `{ node(id: "` + agent1 + `==") { id } }`},
+		{"no database id", `{ node(id: "` + encodeID("Agent") + `") { id } }`},
+		{"unknown type", `{ node(id: "` + encodeID("Publisher:1") + `") { id } }`},
+		{"type without nodes", `{ node(id: "` + encodeID("AuditEntry:1") + `") { id } }`},
+		{"unknown type among nodes", `{ nodes(ids: ["` + agent1 + `", "` + encodeID("Publisher:1") + `"]) { id } }`},
+		{"non-numeric id", `{ node(id: "` + encodeID("Agent:x") + `") { id } }`},
+		{"empty id", `{ node(id: "` + encodeID("Agent:") + `") { id } }`},
+		{"overflowing id", `{ node(id: "` + encodeID("Agent:9223372036854775808") + `") { id } }`},
+		{"typed argument", `{ agent(id: "` + encodeID("Agent:x") + `") { id } }`},
+		{"type mismatch", `{ agent(id: "` + author1 + `") { id } }`},
+		{"type mismatch of book", `{ book(id: "` + agent1 + `") { id } }`},
+		{"type mismatch of reference", `mutation { createAuthor(data: {name: "New", agent_id: "` + book1 + `"}) { id } }`},
+		{"type mismatch in list", `mutation { createBook(data: {title: "New", description: "", cover: "", authorIDs: ["` + author1 + `", "` + agent1 + `"]}) { id } }`},
+		{"type mismatch in filter", `{ authors(filter: {agentIDs: ["` + author1 + `"]}) { totalCount } }`},
+		{"type mismatch of entity", `{ auditLog(filter: {entity: AGENT, entityID: "` + book1 + `"}) { edges { cursor } } }`},
+		{"audit entry as entity", `{ auditLog(filter: {entityID: "` + encodeID("AuditEntry:1") + `"}) { edges { cursor } } }`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data interface{}
+			errs := execute(t, newHandler(t), tt.query, &data)
+			if len(errs) != 1 || errs[0].Extensions.Code != gqlgen.CodeInvalidInput {
+				t.Errorf("errors %+v, want %s", errs, gqlgen.CodeInvalidInput)
+			}
+		})
+	}
+}
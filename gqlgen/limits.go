@@ -0,0 +1,155 @@
+package gqlgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+)
+
+// Limits bound the operations accepted by the handler. Zero values disable
+// the respective check.
+type Limits struct {
+	// MaxDepth is the maximum nesting of fields, root fields have depth 1.
+	// Introspection fields are not counted.
+	MaxDepth int
+	// MaxComplexity is the maximum cost of an operation. Every field costs
+	// 1, and the selections of connections are multiplied by the number of
+	// items requested with first or last.
+	MaxComplexity int
+}
+
+// complexityRoot returns the cost functions of the fields returning lists.
+func complexityRoot() ComplexityRoot {
+	var c ComplexityRoot
+	c.Agent.Authors = func(childComplexity int, first *int, after *string, last *int, before *string) int {
+		return connectionCost(childComplexity, first, last)
+	}
+	c.Author.Books = func(childComplexity int, first *int, after *string, last *int, before *string) int {
+		return connectionCost(childComplexity, first, last)
+	}
+	c.Book.Authors = func(childComplexity int, first *int, after *string, last *int, before *string) int {
+		return connectionCost(childComplexity, first, last)
+	}
+	c.Agent.History = func(childComplexity int, first *int, after *string) int {
+		return connectionCost(childComplexity, first, nil)
+	}
+	c.Author.History = func(childComplexity int, first *int, after *string) int {
+		return connectionCost(childComplexity, first, nil)
+	}
+	c.Book.History = func(childComplexity int, first *int, after *string) int {
+		return connectionCost(childComplexity, first, nil)
+	}
+	c.Query.Agents = func(childComplexity int, filter *pg.AgentFilter, orderBy *AgentOrderBy, first *int, after *string, last *int, before *string, includeDeleted bool) int {
+		return connectionCost(childComplexity, first, last)
+	}
+	c.Query.Authors = func(childComplexity int, filter *AuthorFilter, orderBy *AuthorOrderBy, first *int, after *string, last *int, before *string, includeDeleted bool) int {
+		return connectionCost(childComplexity, first, last)
+	}
+	c.Query.Books = func(childComplexity int, filter *BookFilter, orderBy *BookOrderBy, first *int, after *string, last *int, before *string, includeDeleted bool) int {
+		return connectionCost(childComplexity, first, last)
+	}
+	c.Query.AuditLog = func(childComplexity int, filter *AuditLogFilter, first *int, after *string) int {
+		return connectionCost(childComplexity, first, nil)
+	}
+	c.Query.Nodes = func(childComplexity int, ids []string) int {
+		n := len(ids)
+		return connectionCost(childComplexity, &n, nil)
+	}
+	c.Query.Search = func(childComplexity int, query string, types []SearchType, first *int, after *string) int {
+		return connectionCost(childComplexity, first, nil)
+	}
+	return c
+}
+
+// connectionCost weighs the selections of a connection by the size of the
+// requested page. The result saturates instead of overflowing.
+func connectionCost(childComplexity int, first, last *int) int {
+	n := pg.DefaultPageSize
+	if first != nil {
+		n = *first
+	} else if last != nil {
+		n = *last
+	}
+	if n < 0 {
+		n = 0
+	}
+	if childComplexity > 0 && n > (math.MaxInt32-1)/childComplexity {
+		return math.MaxInt32
+	}
+	return 1 + n*childComplexity
+}
+
+// Error codes of operations rejected by the limits.
+const (
+	CodeDepthLimitExceeded      = "DEPTH_LIMIT_EXCEEDED"
+	CodeComplexityLimitExceeded = "COMPLEXITY_LIMIT_EXCEEDED"
+)
+
+// enforce returns a request middleware rejecting operations which exceed the
+// limits before any of their fields are resolved.
+func (l Limits) enforce(es graphql.ExecutableSchema) graphql.RequestMiddleware {
+	return func(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+		rctx := graphql.GetRequestContext(ctx)
+		op := rctx.Doc.Operations.ForName(rctx.OperationName)
+		if op == nil {
+			return next(ctx)
+		}
+		if l.MaxDepth > 0 {
+			if depth := selectionDepth(op.SelectionSet); depth > l.MaxDepth {
+				graphql.AddError(ctx, limitError(CodeDepthLimitExceeded, "depth", depth, l.MaxDepth))
+				return nil
+			}
+		}
+		if l.MaxComplexity > 0 {
+			if cost := complexity.Calculate(es, op, rctx.Variables); cost > l.MaxComplexity {
+				graphql.AddError(ctx, limitError(CodeComplexityLimitExceeded, "complexity", cost, l.MaxComplexity))
+				return nil
+			}
+		}
+		return next(ctx)
+	}
+}
+
+func limitError(code, measure string, value, limit int) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message: fmt.Sprintf("operation has %s %d, which exceeds the limit of %d", measure, value, limit),
+		Extensions: map[string]interface{}{
+			"code":  code,
+			measure: value,
+			"limit": limit,
+		},
+	}
+}
+
+// selectionDepth returns the deepest nesting of fields in set, following
+// fragments.
+func selectionDepth(set ast.SelectionSet) int {
+	max := 0
+	for _, sel := range set {
+		var depth int
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			depth = 1 + selectionDepth(s.SelectionSet)
+		case *ast.InlineFragment:
+			depth = selectionDepth(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				depth = selectionDepth(s.Definition.SelectionSet)
+			}
+		}
+		if depth > max {
+			max = depth
+		}
+	}
+	return max
+}
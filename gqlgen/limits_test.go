@@ -0,0 +1,169 @@
+package gqlgen_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/memory"      // update the username
+)
+
+// limitResponse is the response to an operation checked by the limits.
+type limitResponse struct {
+	Data   json.RawMessage
+	Errors []struct {
+		Message    string
+		Extensions map[string]interface{}
+	}
+}
+
+// checkLimits executes query with variables against an empty repository
+// served within limits.
+func checkLimits(t *testing.T, limits gqlgen.Limits, query string, variables map[string]interface{}) limitResponse {
+	t.Helper()
+	repo := memory.NewRepository()
+	cfg := dataloaders.DefaultConfig()
+	h := dataloaders.Middleware(repo, cfg)(gqlgen.NewHandler(repo, dataloaders.NewRetriever(), memory.NewBroker(), limits))
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	as(admin, h).ServeHTTP(w, r)
+	var res limitResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body, err)
+	}
+	return res
+}
+
+type limitCase struct {
+	name      string
+	query     string
+	variables map[string]interface{}
+	// want is the measure of a rejected operation, 0 if it is accepted
+	want int
+}
+
+// testLimit checks that the operations of tests exceeding limits are
+// rejected with code, reporting their measure.
+func testLimit(t *testing.T, limits gqlgen.Limits, code, measure string, tests []limitCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := checkLimits(t, limits, tt.query, tt.variables)
+			if tt.want == 0 {
+				if len(res.Errors) > 0 {
+					t.Errorf("errors %+v, want none", res.Errors)
+				}
+				return
+			}
+			if len(res.Errors) != 1 {
+				t.Fatalf("errors %+v, want one", res.Errors)
+			}
+			ext := res.Errors[0].Extensions
+			if ext["code"] != code || ext[measure] != float64(tt.want) {
+				t.Errorf("extensions %v, want code %s and %s %d", ext, code, measure, tt.want)
+			}
+			if string(res.Data) != "null" {
+				t.Errorf("data = %s, want null", res.Data)
+			}
+		})
+	}
+}
+
+func TestDepthLimit(t *testing.T) {
+	agents := `{ agents { edges { node { name } } } }`
+	testLimit(t, gqlgen.Limits{MaxDepth: 3}, gqlgen.CodeDepthLimitExceeded, "depth", []limitCase{
+		{name: "within", query: `{ agents { totalCount edges { cursor } } }`},
+		{name: "exceeding", query: agents, want: 4},
+		{name: "deepest field", query: `{ agents { totalCount edges { node { authors { totalCount } } } } }`, want: 5},
+		{
+			name:  "fragment",
+			query: `{ agents { ...edges } } fragment edges on AgentConnection { edges { node { name } } }`,
+			want:  4,
+		},
+		{
+			name:  "nested fragments",
+			query: `{ ...agents } fragment agents on Query { agents { ...edges } } fragment edges on AgentConnection { edges { cursor } }`,
+		},
+		{
+			// inline fragments do not add a level
+			name:  "inline fragment",
+			query: `{ node(id: "` + agent1 + `") { ... on Agent { authors { totalCount } } } }`,
+		},
+		{
+			name:  "inline fragment exceeding",
+			query: `{ node(id: "` + agent1 + `") { ... on Agent { authors { edges { cursor } } } } }`,
+			want:  4,
+		},
+		{
+			name:  "fragment within inline fragment",
+			query: `{ node(id: "` + agent1 + `") { ... on Agent { ...authors } } } fragment authors on Agent { authors { edges { node { name } } } }`,
+			want:  5,
+		},
+		{
+			// the introspection fields are not counted
+			name:  "introspection",
+			query: `{ __schema { types { name fields { name type { name ofType { name } } } } } }`,
+		},
+		{name: "typename", query: `{ agents { __typename edges { __typename cursor } } }`},
+	})
+}
+
+func TestComplexityLimit(t *testing.T) {
+	// every field costs 1 and the 3 fields of an edge are multiplied by the
+	// size of the page
+	names := `{ agents(first: $n) { edges { node { name } } } }`
+	testLimit(t, gqlgen.Limits{MaxComplexity: 50}, gqlgen.CodeComplexityLimitExceeded, "complexity", []limitCase{
+		{name: "within", query: `{ agents(first: 16) { edges { node { name } } } }`},
+		{name: "first", query: `{ agents(first: 17) { edges { node { name } } } }`, want: 52},
+		{name: "last", query: `{ agents(last: 17) { edges { node { name } } } }`, want: 52},
+		// the default page size applies without first and last
+		{name: "default page", query: `{ agents { edges { node { name } } } }`, want: 301},
+		{name: "first variable within", query: `query($n: Int) ` + names, variables: map[string]interface{}{"n": 16}},
+		{name: "first variable", query: `query($n: Int) ` + names, variables: map[string]interface{}{"n": 17}, want: 52},
+		{name: "first variable default", query: `query($n: Int = 17) ` + names, want: 52},
+		{
+			name:      "last variable",
+			query:     `query($n: Int) { agents(last: $n) { edges { node { name } } } }`,
+			variables: map[string]interface{}{"n": 20},
+			want:      61,
+		},
+		{
+			name:  "nested",
+			query: `{ agents(first: 5) { edges { node { authors(first: 3) { edges { node { name } } } } } } }`,
+			want:  1 + 5*(1+1+(1+3*3)),
+		},
+		{
+			name:  "fragment",
+			query: `{ agents(first: 17) { ...edges } } fragment edges on AgentConnection { edges { node { name } } }`,
+			want:  52,
+		},
+		{
+			name:  "inline fragment",
+			query: `{ agents(first: 17) { ... on AgentConnection { edges { node { name } } } } }`,
+			want:  52,
+		},
+		{
+			name:  "saturating",
+			query: `{ agents(first: 1000) { edges { node { authors(first: 1000) { edges { node { books(first: 1000) { edges { node { title } } } } } } } } } }`,
+			want:  math.MaxInt32,
+		},
+		{name: "introspection", query: `{ __schema { queryType { name } } }`},
+	})
+}
+
+// Cyclic fragments are rejected by the validation, before the limits are
+// checked.
+func TestLimitsFragmentCycle(t *testing.T) {
+	query := `{ ...a } fragment a on Query { agents { ...b } } fragment b on AgentConnection { edges { node { authors { ...b } } } }`
+	res := checkLimits(t, gqlgen.Limits{MaxDepth: 100, MaxComplexity: 100}, query, nil)
+	if len(res.Errors) == 0 || res.Errors[0].Extensions["code"] != nil {
+		t.Errorf("errors %+v, want a validation error", res.Errors)
+	}
+}
@@ -2,20 +2,409 @@
 
 package gqlgen
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"
+)
+
+type AgentChange struct {
+	Action ChangeAction `json:"action"`
+	Agent  *pg.Agent    `json:"agent"`
+}
+
 type AgentInput struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
 }
 
+type AuditLogFilter struct {
+	Entity    *AuditEntity    `json:"entity"`
+	EntityID  *string         `json:"entityID"`
+	Operation *AuditOperation `json:"operation"`
+	Actor     *string         `json:"actor"`
+	Since     *time.Time      `json:"since"`
+	Until     *time.Time      `json:"until"`
+}
+
+type AuthorChange struct {
+	Action ChangeAction `json:"action"`
+	Author *pg.Author   `json:"author"`
+}
+
 type AuthorInput struct {
 	Name    string  `json:"name"`
 	Website *string `json:"website"`
-	AgentID int64   `json:"agent_id"`
+	AgentID string  `json:"agent_id"`
+}
+
+type BookChange struct {
+	Action ChangeAction `json:"action"`
+	Book   *pg.Book     `json:"book"`
 }
 
 type BookInput struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Cover       string  `json:"cover"`
-	AuthorIDs   []int64 `json:"authorIDs"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Cover       string   `json:"cover"`
+	AuthorIDs   []string `json:"authorIDs"`
+}
+
+type AgentOrderBy string
+
+const (
+	AgentOrderByNameAsc   AgentOrderBy = "NAME_ASC"
+	AgentOrderByNameDesc  AgentOrderBy = "NAME_DESC"
+	AgentOrderByEmailAsc  AgentOrderBy = "EMAIL_ASC"
+	AgentOrderByEmailDesc AgentOrderBy = "EMAIL_DESC"
+	AgentOrderByIDAsc     AgentOrderBy = "ID_ASC"
+	AgentOrderByIDDesc    AgentOrderBy = "ID_DESC"
+)
+
+var AllAgentOrderBy = []AgentOrderBy{
+	AgentOrderByNameAsc,
+	AgentOrderByNameDesc,
+	AgentOrderByEmailAsc,
+	AgentOrderByEmailDesc,
+	AgentOrderByIDAsc,
+	AgentOrderByIDDesc,
+}
+
+func (e AgentOrderBy) IsValid() bool {
+	switch e {
+	case AgentOrderByNameAsc, AgentOrderByNameDesc, AgentOrderByEmailAsc, AgentOrderByEmailDesc, AgentOrderByIDAsc, AgentOrderByIDDesc:
+		return true
+	}
+	return false
+}
+
+func (e AgentOrderBy) String() string {
+	return string(e)
+}
+
+func (e *AgentOrderBy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AgentOrderBy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AgentOrderBy", str)
+	}
+	return nil
+}
+
+func (e AgentOrderBy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AuditEntity string
+
+const (
+	AuditEntityAgent  AuditEntity = "AGENT"
+	AuditEntityAuthor AuditEntity = "AUTHOR"
+	AuditEntityBook   AuditEntity = "BOOK"
+)
+
+var AllAuditEntity = []AuditEntity{
+	AuditEntityAgent,
+	AuditEntityAuthor,
+	AuditEntityBook,
+}
+
+func (e AuditEntity) IsValid() bool {
+	switch e {
+	case AuditEntityAgent, AuditEntityAuthor, AuditEntityBook:
+		return true
+	}
+	return false
+}
+
+func (e AuditEntity) String() string {
+	return string(e)
+}
+
+func (e *AuditEntity) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditEntity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditEntity", str)
+	}
+	return nil
+}
+
+func (e AuditEntity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AuditOperation string
+
+const (
+	AuditOperationCreate  AuditOperation = "CREATE"
+	AuditOperationUpdate  AuditOperation = "UPDATE"
+	AuditOperationDelete  AuditOperation = "DELETE"
+	AuditOperationRestore AuditOperation = "RESTORE"
+)
+
+var AllAuditOperation = []AuditOperation{
+	AuditOperationCreate,
+	AuditOperationUpdate,
+	AuditOperationDelete,
+	AuditOperationRestore,
+}
+
+func (e AuditOperation) IsValid() bool {
+	switch e {
+	case AuditOperationCreate, AuditOperationUpdate, AuditOperationDelete, AuditOperationRestore:
+		return true
+	}
+	return false
+}
+
+func (e AuditOperation) String() string {
+	return string(e)
+}
+
+func (e *AuditOperation) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuditOperation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditOperation", str)
+	}
+	return nil
+}
+
+func (e AuditOperation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type AuthorOrderBy string
+
+const (
+	AuthorOrderByNameAsc  AuthorOrderBy = "NAME_ASC"
+	AuthorOrderByNameDesc AuthorOrderBy = "NAME_DESC"
+	AuthorOrderByIDAsc    AuthorOrderBy = "ID_ASC"
+	AuthorOrderByIDDesc   AuthorOrderBy = "ID_DESC"
+)
+
+var AllAuthorOrderBy = []AuthorOrderBy{
+	AuthorOrderByNameAsc,
+	AuthorOrderByNameDesc,
+	AuthorOrderByIDAsc,
+	AuthorOrderByIDDesc,
+}
+
+func (e AuthorOrderBy) IsValid() bool {
+	switch e {
+	case AuthorOrderByNameAsc, AuthorOrderByNameDesc, AuthorOrderByIDAsc, AuthorOrderByIDDesc:
+		return true
+	}
+	return false
+}
+
+func (e AuthorOrderBy) String() string {
+	return string(e)
+}
+
+func (e *AuthorOrderBy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AuthorOrderBy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AuthorOrderBy", str)
+	}
+	return nil
+}
+
+func (e AuthorOrderBy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type BookOrderBy string
+
+const (
+	BookOrderByTitleAsc  BookOrderBy = "TITLE_ASC"
+	BookOrderByTitleDesc BookOrderBy = "TITLE_DESC"
+	BookOrderByIDAsc     BookOrderBy = "ID_ASC"
+	BookOrderByIDDesc    BookOrderBy = "ID_DESC"
+)
+
+var AllBookOrderBy = []BookOrderBy{
+	BookOrderByTitleAsc,
+	BookOrderByTitleDesc,
+	BookOrderByIDAsc,
+	BookOrderByIDDesc,
+}
+
+func (e BookOrderBy) IsValid() bool {
+	switch e {
+	case BookOrderByTitleAsc, BookOrderByTitleDesc, BookOrderByIDAsc, BookOrderByIDDesc:
+		return true
+	}
+	return false
+}
+
+func (e BookOrderBy) String() string {
+	return string(e)
+}
+
+func (e *BookOrderBy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BookOrderBy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BookOrderBy", str)
+	}
+	return nil
+}
+
+func (e BookOrderBy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ChangeAction string
+
+const (
+	ChangeActionCreated  ChangeAction = "CREATED"
+	ChangeActionUpdated  ChangeAction = "UPDATED"
+	ChangeActionDeleted  ChangeAction = "DELETED"
+	ChangeActionRestored ChangeAction = "RESTORED"
+)
+
+var AllChangeAction = []ChangeAction{
+	ChangeActionCreated,
+	ChangeActionUpdated,
+	ChangeActionDeleted,
+	ChangeActionRestored,
+}
+
+func (e ChangeAction) IsValid() bool {
+	switch e {
+	case ChangeActionCreated, ChangeActionUpdated, ChangeActionDeleted, ChangeActionRestored:
+		return true
+	}
+	return false
+}
+
+func (e ChangeAction) String() string {
+	return string(e)
+}
+
+func (e *ChangeAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ChangeAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ChangeAction", str)
+	}
+	return nil
+}
+
+func (e ChangeAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Role string
+
+const (
+	RoleAdmin  Role = "ADMIN"
+	RoleEditor Role = "EDITOR"
+)
+
+var AllRole = []Role{
+	RoleAdmin,
+	RoleEditor,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleAdmin, RoleEditor:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SearchType string
+
+const (
+	SearchTypeAgent  SearchType = "AGENT"
+	SearchTypeAuthor SearchType = "AUTHOR"
+	SearchTypeBook   SearchType = "BOOK"
+)
+
+var AllSearchType = []SearchType{
+	SearchTypeAgent,
+	SearchTypeAuthor,
+	SearchTypeBook,
+}
+
+func (e SearchType) IsValid() bool {
+	switch e {
+	case SearchTypeAgent, SearchTypeAuthor, SearchTypeBook:
+		return true
+	}
+	return false
+}
+
+func (e SearchType) String() string {
+	return string(e)
+}
+
+func (e *SearchType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchType", str)
+	}
+	return nil
+}
+
+func (e SearchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
@@ -0,0 +1,181 @@
+package gqlgen
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/handler"
+	"github.com/vektah/gqlparser/ast"
+	"github.com/vektah/gqlparser/gqlerror"
+	"github.com/vektah/gqlparser/parser"
+)
+
+// PersistedQueryStore holds the text of persisted queries by the hex encoded
+// sha256 hash of the text. The queries imported from manifests are kept
+// apart from those registered by clients, which the store may evict. The
+// getters return pg.ErrNotFound for unknown hashes. It is implemented by
+// pg.PersistedQueryStore and memory.PersistedQueryStore.
+type PersistedQueryStore interface {
+	// Get returns a registered or imported query.
+	Get(ctx context.Context, hash string) (string, error)
+	// GetImported returns an imported query.
+	GetImported(ctx context.Context, hash string) (string, error)
+	// Put registers a query sent by a client.
+	Put(ctx context.Context, hash, query string) error
+	// Import stores a query of a manifest.
+	Import(ctx context.Context, hash, query string) error
+}
+
+// PersistedQueries configures automatic persisted queries: clients may send
+// the sha256 hash of a query instead of its text, and register the text when
+// the hash is unknown.
+type PersistedQueries struct {
+	Store PersistedQueryStore
+	// Allowlist executes only the queries imported into the store, which
+	// clients may not register, regardless of whether they send the hash or
+	// the text.
+	Allowlist bool
+	// Logger logs the queries which could not be registered, it may be nil.
+	Logger interface {
+		Warn(msg string, kv ...interface{})
+	}
+}
+
+// CodePersistedQueryNotAllowed is reported for queries missing from the
+// allowlist.
+const CodePersistedQueryNotAllowed = "PERSISTED_QUERY_NOT_ALLOWED"
+
+// HandlerOptions returns the options of the GraphQL handler serving
+// persisted queries from the store.
+func (p PersistedQueries) HandlerOptions() []handler.Option {
+	options := []handler.Option{handler.EnablePersistedQueryCache(apqCache{p})}
+	if p.Allowlist {
+		options = append(options, handler.RequestMiddleware(p.enforceAllowlist))
+	}
+	return options
+}
+
+// apqCache adapts the store to the handler, which has no way of reporting
+// store errors. A failed lookup makes clients send the query text instead.
+type apqCache struct {
+	p PersistedQueries
+}
+
+func (c apqCache) Get(ctx context.Context, hash string) (string, bool) {
+	get := c.p.Store.Get
+	if c.p.Allowlist {
+		get = c.p.Store.GetImported
+	}
+	query, err := get(ctx, hash)
+	return query, err == nil
+}
+
+func (c apqCache) Add(ctx context.Context, hash, query string) {
+	if c.p.Allowlist {
+		return
+	}
+	// the query is executed anyway, the client registers it again next time
+	if err := c.p.Store.Put(ctx, hash, query); err != nil && c.p.Logger != nil {
+		c.p.Logger.Warn("persisted query registration failed", "hash", hash, "error", err.Error())
+	}
+}
+
+func (p PersistedQueries) enforceAllowlist(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+	rctx := graphql.GetRequestContext(ctx)
+	if _, err := p.Store.GetImported(ctx, QueryHash(rctx.RawQuery)); err != nil {
+		graphql.AddError(ctx, &gqlerror.Error{
+			Message:    "query is not in the persisted query allowlist",
+			Extensions: map[string]interface{}{"code": CodePersistedQueryNotAllowed},
+		})
+		return nil
+	}
+	return next(ctx)
+}
+
+// QueryHash returns the hex encoded sha256 hash identifying query.
+func QueryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// ReadManifest reads a persisted query manifest, which is either a JSON
+// object mapping hashes to queries or an Apollo persisted query manifest:
+//
+//	{"format": "apollo-persisted-query-manifest", "version": 1,
+//	 "operations": [{"id": "<hash>", "name": "...", "body": "<query>"}]}
+//
+// Every hash must be the QueryHash of its query.
+func ReadManifest(r io.Reader) (map[string]string, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("manifest: %v", err)
+	}
+	var apollo struct {
+		Format     string `json:"format"`
+		Version    int    `json:"version"`
+		Operations []struct {
+			ID   string `json:"id"`
+			Body string `json:"body"`
+		} `json:"operations"`
+	}
+	queries := make(map[string]string)
+	if err := json.Unmarshal(b, &apollo); err == nil && apollo.Format != "" {
+		if apollo.Format != "apollo-persisted-query-manifest" || apollo.Version != 1 {
+			return nil, fmt.Errorf("manifest: unsupported format %q version %d", apollo.Format, apollo.Version)
+		}
+		for _, op := range apollo.Operations {
+			queries[op.ID] = op.Body
+		}
+	} else if err := json.Unmarshal(b, &queries); err != nil {
+		return nil, fmt.Errorf("manifest: %v", err)
+	}
+	for hash, query := range queries {
+		if QueryHash(query) != hash {
+			return nil, fmt.Errorf("manifest: %s: hash does not match the query", hash)
+		}
+	}
+	if len(queries) == 0 {
+		return nil, errors.New("manifest: no queries")
+	}
+	return queries, nil
+}
+
+// ImportManifest imports the queries of a manifest into the store and returns
+// them by hash.
+func ImportManifest(ctx context.Context, store PersistedQueryStore, r io.Reader) (map[string]string, error) {
+	queries, err := ReadManifest(r)
+	if err != nil {
+		return nil, err
+	}
+	for hash, query := range queries {
+		if err := store.Import(ctx, hash, query); err != nil {
+			return nil, fmt.Errorf("manifest: %s: %w", hash, err)
+		}
+	}
+	return queries, nil
+}
+
+// OperationNames returns the names of the named operations of queries.
+// Queries which do not parse are skipped, they fail when executed.
+func OperationNames(queries map[string]string) []string {
+	var names []string
+	for _, query := range queries {
+		doc, err := parser.ParseQuery(&ast.Source{Input: query})
+		if err != nil {
+			continue
+		}
+		for _, op := range doc.Operations {
+			if op.Name != "" {
+				names = append(names, op.Name)
+			}
+		}
+	}
+	return names
+}
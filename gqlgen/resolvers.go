@@ -2,6 +2,11 @@ package gqlgen
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
 	"github.com/fwojciec/gqlgen-sqlc-example/pg"          // update the username
@@ -11,6 +16,7 @@ import (
 type Resolver struct {
 	Repository  pg.Repository
 	DataLoaders dataloaders.Retriever
+	Broker      Broker
 }
 
 // Agent returns an implementation of the AgentResolver interface.
@@ -18,6 +24,11 @@ func (r *Resolver) Agent() AgentResolver {
 	return &agentResolver{r}
 }
 
+// AuditEntry returns an implementation of the AuditEntryResolver interface.
+func (r *Resolver) AuditEntry() AuditEntryResolver {
+	return &auditEntryResolver{r}
+}
+
 // Author returns an implementation of the AuthorResolver interface.
 func (r *Resolver) Author() AuthorResolver {
 	return &authorResolver{r}
@@ -28,6 +39,12 @@ func (r *Resolver) Book() BookResolver {
 	return &bookResolver{r}
 }
 
+// FieldChange returns an implementation of the FieldChangeResolver
+// interface.
+func (r *Resolver) FieldChange() FieldChangeResolver {
+	return &fieldChangeResolver{r}
+}
+
 // Mutation returns an implementation of the MutationResolver interface.
 func (r *Resolver) Mutation() MutationResolver {
 	return &mutationResolver{r}
@@ -38,14 +55,80 @@ func (r *Resolver) Query() QueryResolver {
 	return &queryResolver{r}
 }
 
+// Subscription returns an implementation of the SubscriptionResolver
+// interface.
+func (r *Resolver) Subscription() SubscriptionResolver {
+	return &subscriptionResolver{r}
+}
+
 type agentResolver struct{ *Resolver }
 
-func (r *agentResolver) Authors(ctx context.Context, obj *pg.Agent) ([]pg.Author, error) {
-	return r.DataLoaders.Retrieve(ctx).AuthorsByAgentID.Load(obj.ID)
+func (r *agentResolver) ID(ctx context.Context, obj *pg.Agent) (string, error) {
+	return globalID(typeAgent, obj.ID), nil
+}
+
+func (r *agentResolver) Authors(ctx context.Context, obj *pg.Agent, first *int, after *string, last *int, before *string) (*pg.AuthorConnection, error) {
+	page, err := pg.NewPage(pg.Order{}, first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+	return r.DataLoaders.Retrieve(ctx).AuthorsByAgentID.Load(dataloaders.PageKey{ID: obj.ID, Page: page})
+}
+
+func (r *agentResolver) DeletedAt(ctx context.Context, obj *pg.Agent) (*time.Time, error) {
+	if obj.DeletedAt.Valid {
+		return &obj.DeletedAt.Time, nil
+	}
+	return nil, nil
+}
+
+func (r *agentResolver) History(ctx context.Context, obj *pg.Agent, first *int, after *string) (*pg.AuditEntryConnection, error) {
+	page, err := pg.NewPage(pg.AuditOrder, first, after, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.DataLoaders.Retrieve(ctx).HistoryByAgentID.Load(dataloaders.PageKey{ID: obj.ID, Page: page})
+}
+
+type auditEntryResolver struct{ *Resolver }
+
+func (r *auditEntryResolver) ID(ctx context.Context, obj *pg.AuditEntry) (string, error) {
+	return globalID(typeAuditEntry, obj.ID), nil
+}
+
+func (r *auditEntryResolver) Entity(ctx context.Context, obj *pg.AuditEntry) (AuditEntity, error) {
+	return AuditEntity(strings.ToUpper(obj.Entity)), nil
+}
+
+func (r *auditEntryResolver) EntityID(ctx context.Context, obj *pg.AuditEntry) (string, error) {
+	return globalID(entityTypes[obj.Entity], obj.EntityID), nil
+}
+
+func (r *auditEntryResolver) Operation(ctx context.Context, obj *pg.AuditEntry) (AuditOperation, error) {
+	return AuditOperation(strings.ToUpper(obj.Op)), nil
+}
+
+func (r *auditEntryResolver) Actor(ctx context.Context, obj *pg.AuditEntry) (*string, error) {
+	if obj.Actor.Valid {
+		return &obj.Actor.String, nil
+	}
+	return nil, nil
+}
+
+func (r *auditEntryResolver) Timestamp(ctx context.Context, obj *pg.AuditEntry) (*time.Time, error) {
+	return &obj.ChangedAt, nil
+}
+
+func (r *auditEntryResolver) Changes(ctx context.Context, obj *pg.AuditEntry) ([]pg.FieldChange, error) {
+	return obj.FieldChanges()
 }
 
 type authorResolver struct{ *Resolver }
 
+func (r *authorResolver) ID(ctx context.Context, obj *pg.Author) (string, error) {
+	return globalID(typeAuthor, obj.ID), nil
+}
+
 func (r *authorResolver) Website(ctx context.Context, obj *pg.Author) (*string, error) {
 	var w string
 	if obj.Website.Valid {
@@ -59,14 +142,66 @@ func (r *authorResolver) Agent(ctx context.Context, obj *pg.Author) (*pg.Agent,
 	return r.DataLoaders.Retrieve(ctx).AgentByAuthorID.Load(obj.ID)
 }
 
-func (r *authorResolver) Books(ctx context.Context, obj *pg.Author) ([]pg.Book, error) {
-	return r.DataLoaders.Retrieve(ctx).BooksByAuthorID.Load(obj.ID)
+func (r *authorResolver) DeletedAt(ctx context.Context, obj *pg.Author) (*time.Time, error) {
+	if obj.DeletedAt.Valid {
+		return &obj.DeletedAt.Time, nil
+	}
+	return nil, nil
+}
+
+func (r *authorResolver) Books(ctx context.Context, obj *pg.Author, first *int, after *string, last *int, before *string) (*pg.BookConnection, error) {
+	page, err := pg.NewPage(pg.Order{}, first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+	return r.DataLoaders.Retrieve(ctx).BooksByAuthorID.Load(dataloaders.PageKey{ID: obj.ID, Page: page})
+}
+
+func (r *authorResolver) History(ctx context.Context, obj *pg.Author, first *int, after *string) (*pg.AuditEntryConnection, error) {
+	page, err := pg.NewPage(pg.AuditOrder, first, after, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.DataLoaders.Retrieve(ctx).HistoryByAuthorID.Load(dataloaders.PageKey{ID: obj.ID, Page: page})
 }
 
 type bookResolver struct{ *Resolver }
 
-func (r *bookResolver) Authors(ctx context.Context, obj *pg.Book) ([]pg.Author, error) {
-	return r.DataLoaders.Retrieve(ctx).AuthorsByBookID.Load(obj.ID)
+func (r *bookResolver) ID(ctx context.Context, obj *pg.Book) (string, error) {
+	return globalID(typeBook, obj.ID), nil
+}
+
+func (r *bookResolver) Authors(ctx context.Context, obj *pg.Book, first *int, after *string, last *int, before *string) (*pg.AuthorConnection, error) {
+	page, err := pg.NewPage(pg.Order{}, first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+	return r.DataLoaders.Retrieve(ctx).AuthorsByBookID.Load(dataloaders.PageKey{ID: obj.ID, Page: page})
+}
+
+func (r *bookResolver) DeletedAt(ctx context.Context, obj *pg.Book) (*time.Time, error) {
+	if obj.DeletedAt.Valid {
+		return &obj.DeletedAt.Time, nil
+	}
+	return nil, nil
+}
+
+func (r *bookResolver) History(ctx context.Context, obj *pg.Book, first *int, after *string) (*pg.AuditEntryConnection, error) {
+	page, err := pg.NewPage(pg.AuditOrder, first, after, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.DataLoaders.Retrieve(ctx).HistoryByBookID.Load(dataloaders.PageKey{ID: obj.ID, Page: page})
+}
+
+type fieldChangeResolver struct{ *Resolver }
+
+func (r *fieldChangeResolver) Old(ctx context.Context, obj *pg.FieldChange) (interface{}, error) {
+	return obj.Old, nil
+}
+
+func (r *fieldChangeResolver) New(ctx context.Context, obj *pg.FieldChange) (interface{}, error) {
+	return obj.New, nil
 }
 
 type mutationResolver struct{ *Resolver }
@@ -79,122 +214,625 @@ func (r *mutationResolver) CreateAgent(ctx context.Context, data AgentInput) (*p
 	if err != nil {
 		return nil, err
 	}
+	r.publish(ctx, topicAgentChanged, entityEvent{Action: ChangeActionCreated, ID: agent.ID})
+	r.DataLoaders.Retrieve(ctx).AgentChanged(&agent)
 	return &agent, nil
 }
 
-func (r *mutationResolver) UpdateAgent(ctx context.Context, id int64, data AgentInput) (*pg.Agent, error) {
+func (r *mutationResolver) UpdateAgent(ctx context.Context, gid string, data AgentInput, expectedVersion *int) (*pg.Agent, error) {
+	id, err := decodeID(typeAgent, gid)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := version(expectedVersion)
+	if err != nil {
+		return nil, err
+	}
 	agent, err := r.Repository.UpdateAgent(ctx, pg.UpdateAgentParams{
 		ID:    id,
 		Name:  data.Name,
 		Email: data.Email,
-	})
+	}, expected)
 	if err != nil {
 		return nil, err
 	}
+	r.publish(ctx, topicAgentChanged, entityEvent{Action: ChangeActionUpdated, ID: agent.ID})
+	r.DataLoaders.Retrieve(ctx).AgentChanged(&agent)
 	return &agent, nil
 }
 
-func (r *mutationResolver) DeleteAgent(ctx context.Context, id int64) (*pg.Agent, error) {
+func (r *mutationResolver) DeleteAgent(ctx context.Context, gid string) (*pg.Agent, error) {
+	id, err := decodeID(typeAgent, gid)
+	if err != nil {
+		return nil, err
+	}
 	agent, err := r.Repository.DeleteAgent(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	r.publish(ctx, topicAgentChanged, entityEvent{Action: ChangeActionDeleted, ID: agent.ID})
+	r.DataLoaders.Retrieve(ctx).AgentChanged(&agent)
+	return &agent, nil
+}
+
+func (r *mutationResolver) RestoreAgent(ctx context.Context, gid string) (*pg.Agent, error) {
+	id, err := decodeID(typeAgent, gid)
+	if err != nil {
+		return nil, err
+	}
+	agent, err := r.Repository.RestoreAgent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, topicAgentChanged, entityEvent{Action: ChangeActionRestored, ID: agent.ID})
+	r.DataLoaders.Retrieve(ctx).AgentChanged(&agent)
 	return &agent, nil
 }
 
 func (r *mutationResolver) CreateAuthor(ctx context.Context, data AuthorInput) (*pg.Author, error) {
+	agentID, err := decodeID(typeAgent, data.AgentID)
+	if err != nil {
+		return nil, err
+	}
 	author, err := r.Repository.CreateAuthor(ctx, pg.CreateAuthorParams{
 		Name:    data.Name,
 		Website: pg.StringPtrToNullString(data.Website),
-		AgentID: data.AgentID,
+		AgentID: agentID,
 	})
 	if err != nil {
 		return nil, err
 	}
+	r.publish(ctx, topicAuthorChanged, entityEvent{Action: ChangeActionCreated, ID: author.ID})
+	r.DataLoaders.Retrieve(ctx).AuthorChanged(&author)
 	return &author, nil
 }
 
-func (r *mutationResolver) UpdateAuthor(ctx context.Context, id int64, data AuthorInput) (*pg.Author, error) {
+func (r *mutationResolver) UpdateAuthor(ctx context.Context, gid string, data AuthorInput, expectedVersion *int) (*pg.Author, error) {
+	id, err := decodeID(typeAuthor, gid)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := version(expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	agentID, err := decodeID(typeAgent, data.AgentID)
+	if err != nil {
+		return nil, err
+	}
 	author, err := r.Repository.UpdateAuthor(ctx, pg.UpdateAuthorParams{
 		ID:      id,
 		Name:    data.Name,
 		Website: pg.StringPtrToNullString(data.Website),
-		AgentID: data.AgentID,
-	})
+		AgentID: agentID,
+	}, expected)
 	if err != nil {
 		return nil, err
 	}
+	r.publish(ctx, topicAuthorChanged, entityEvent{Action: ChangeActionUpdated, ID: author.ID})
+	r.DataLoaders.Retrieve(ctx).AuthorChanged(&author)
 	return &author, nil
 }
 
-func (r *mutationResolver) DeleteAuthor(ctx context.Context, id int64) (*pg.Author, error) {
+func (r *mutationResolver) DeleteAuthor(ctx context.Context, gid string) (*pg.Author, error) {
+	id, err := decodeID(typeAuthor, gid)
+	if err != nil {
+		return nil, err
+	}
 	author, err := r.Repository.DeleteAuthor(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	r.publish(ctx, topicAuthorChanged, entityEvent{Action: ChangeActionDeleted, ID: author.ID})
+	r.DataLoaders.Retrieve(ctx).AuthorChanged(&author)
+	return &author, nil
+}
+
+func (r *mutationResolver) RestoreAuthor(ctx context.Context, gid string) (*pg.Author, error) {
+	id, err := decodeID(typeAuthor, gid)
+	if err != nil {
+		return nil, err
+	}
+	author, err := r.Repository.RestoreAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, topicAuthorChanged, entityEvent{Action: ChangeActionRestored, ID: author.ID})
+	r.DataLoaders.Retrieve(ctx).AuthorChanged(&author)
 	return &author, nil
 }
 
 func (r *mutationResolver) CreateBook(ctx context.Context, data BookInput) (*pg.Book, error) {
-	return r.Repository.CreateBook(ctx, pg.CreateBookParams{
+	authorIDs, err := decodeIDs(typeAuthor, data.AuthorIDs)
+	if err != nil {
+		return nil, err
+	}
+	book, err := r.Repository.CreateBook(ctx, pg.CreateBookParams{
 		Title:       data.Title,
 		Description: data.Description,
 		Cover:       data.Cover,
-	}, data.AuthorIDs)
+	}, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, topicBookChanged, entityEvent{Action: ChangeActionCreated, ID: book.ID})
+	r.DataLoaders.Retrieve(ctx).BookChanged(book)
+	for _, authorID := range authorIDs {
+		r.publish(ctx, topicBookAdded, bookAdded{BookID: book.ID, AuthorID: authorID})
+	}
+	return book, nil
 }
 
-func (r *mutationResolver) UpdateBook(ctx context.Context, id int64, data BookInput) (*pg.Book, error) {
-	return r.Repository.UpdateBook(ctx, pg.UpdateBookParams{
+func (r *mutationResolver) UpdateBook(ctx context.Context, gid string, data BookInput, expectedVersion *int) (*pg.Book, error) {
+	id, err := decodeID(typeBook, gid)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := version(expectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	authorIDs, err := decodeIDs(typeAuthor, data.AuthorIDs)
+	if err != nil {
+		return nil, err
+	}
+	book, added, err := r.Repository.UpdateBook(ctx, pg.UpdateBookParams{
 		ID:          id,
 		Title:       data.Title,
 		Description: data.Description,
 		Cover:       data.Cover,
-	}, data.AuthorIDs)
+	}, authorIDs, expected)
+	if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, topicBookChanged, entityEvent{Action: ChangeActionUpdated, ID: book.ID})
+	r.DataLoaders.Retrieve(ctx).BookChanged(book)
+	for _, authorID := range added {
+		r.publish(ctx, topicBookAdded, bookAdded{BookID: book.ID, AuthorID: authorID})
+	}
+	return book, nil
 }
 
-func (r *mutationResolver) DeleteBook(ctx context.Context, id int64) (*pg.Book, error) {
-	// BookAuthors associations will cascade automatically.
+func (r *mutationResolver) DeleteBook(ctx context.Context, gid string) (*pg.Book, error) {
+	id, err := decodeID(typeBook, gid)
+	if err != nil {
+		return nil, err
+	}
+	// BookAuthors associations are kept for the book to be restored with.
 	book, err := r.Repository.DeleteBook(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	r.publish(ctx, topicBookChanged, entityEvent{Action: ChangeActionDeleted, ID: book.ID})
+	r.DataLoaders.Retrieve(ctx).BookChanged(&book)
 	return &book, nil
 }
 
+func (r *mutationResolver) RestoreBook(ctx context.Context, gid string) (*pg.Book, error) {
+	id, err := decodeID(typeBook, gid)
+	if err != nil {
+		return nil, err
+	}
+	book, err := r.Repository.RestoreBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, topicBookChanged, entityEvent{Action: ChangeActionRestored, ID: book.ID})
+	r.DataLoaders.Retrieve(ctx).BookChanged(&book)
+	return &book, nil
+}
+
+// version converts the expectedVersion argument of the updates, which must
+// fit the int32 versions of the entities.
+func version(v *int) (*int32, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if *v < math.MinInt32 || *v > math.MaxInt32 {
+		return nil, fmt.Errorf("%w: expectedVersion %d is out of range", pg.ErrInvalidInput, *v)
+	}
+	v32 := int32(*v)
+	return &v32, nil
+}
+
 type queryResolver struct{ *Resolver }
 
-func (r *queryResolver) Agent(ctx context.Context, id int64) (*pg.Agent, error) {
-	agent, err := r.Repository.GetAgent(ctx, id)
+func (r *queryResolver) Agent(ctx context.Context, gid string, includeDeleted bool) (*pg.Agent, error) {
+	id, err := decodeID(typeAgent, gid)
 	if err != nil {
 		return nil, err
 	}
-	return &agent, nil
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+	agent, err := r.DataLoaders.Retrieve(ctx).AgentByID.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil || agent.DeletedAt.Valid && !includeDeleted {
+		return nil, nil
+	}
+	return agent, nil
 }
 
-func (r *queryResolver) Agents(ctx context.Context) ([]pg.Agent, error) {
-	return r.Repository.ListAgents(ctx)
+func (r *queryResolver) Agents(ctx context.Context, filter *pg.AgentFilter, orderBy *AgentOrderBy, first *int, after *string, last *int, before *string, includeDeleted bool) (*pg.AgentConnection, error) {
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+	var order pg.Order
+	if orderBy != nil {
+		order = agentOrders[*orderBy]
+	}
+	page, err := pg.NewPage(order, first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+	var f pg.AgentFilter
+	if filter != nil {
+		f = *filter
+	}
+	f.IncludeDeleted = includeDeleted
+	items, err := r.Repository.ListAgents(ctx, f, page)
+	if err != nil {
+		return nil, err
+	}
+	count, err := r.Repository.CountAgents(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return pg.NewAgentConnection(page, items, count), nil
 }
 
-func (r *queryResolver) Author(ctx context.Context, id int64) (*pg.Author, error) {
-	author, err := r.Repository.GetAuthor(ctx, id)
+func (r *queryResolver) Author(ctx context.Context, gid string, includeDeleted bool) (*pg.Author, error) {
+	id, err := decodeID(typeAuthor, gid)
 	if err != nil {
 		return nil, err
 	}
-	return &author, nil
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+	author, err := r.DataLoaders.Retrieve(ctx).AuthorByID.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if author == nil || author.DeletedAt.Valid && !includeDeleted {
+		return nil, nil
+	}
+	return author, nil
 }
 
-func (r *queryResolver) Authors(ctx context.Context) ([]pg.Author, error) {
-	return r.Repository.ListAuthors(ctx)
+func (r *queryResolver) Authors(ctx context.Context, filter *AuthorFilter, orderBy *AuthorOrderBy, first *int, after *string, last *int, before *string, includeDeleted bool) (*pg.AuthorConnection, error) {
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+	var order pg.Order
+	if orderBy != nil {
+		order = authorOrders[*orderBy]
+	}
+	page, err := pg.NewPage(order, first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+	f, err := filter.decode()
+	if err != nil {
+		return nil, err
+	}
+	f.IncludeDeleted = includeDeleted
+	items, err := r.Repository.ListAuthors(ctx, f, page)
+	if err != nil {
+		return nil, err
+	}
+	count, err := r.Repository.CountAuthors(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return pg.NewAuthorConnection(page, items, count), nil
 }
 
-func (r *queryResolver) Book(ctx context.Context, id int64) (*pg.Book, error) {
-	book, err := r.Repository.GetBook(ctx, id)
+func (r *queryResolver) Book(ctx context.Context, gid string, includeDeleted bool) (*pg.Book, error) {
+	id, err := decodeID(typeBook, gid)
 	if err != nil {
 		return nil, err
 	}
-	return &book, nil
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+	book, err := r.DataLoaders.Retrieve(ctx).BookByID.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil || book.DeletedAt.Valid && !includeDeleted {
+		return nil, nil
+	}
+	return book, nil
 }
 
-func (r *queryResolver) Books(ctx context.Context) ([]pg.Book, error) {
-	return r.Repository.ListBooks(ctx)
+func (r *queryResolver) Books(ctx context.Context, filter *BookFilter, orderBy *BookOrderBy, first *int, after *string, last *int, before *string, includeDeleted bool) (*pg.BookConnection, error) {
+	if err := checkIncludeDeleted(ctx, includeDeleted); err != nil {
+		return nil, err
+	}
+	var order pg.Order
+	if orderBy != nil {
+		order = bookOrders[*orderBy]
+	}
+	page, err := pg.NewPage(order, first, after, last, before)
+	if err != nil {
+		return nil, err
+	}
+	f, err := filter.decode()
+	if err != nil {
+		return nil, err
+	}
+	f.IncludeDeleted = includeDeleted
+	items, err := r.Repository.ListBooks(ctx, f, page)
+	if err != nil {
+		return nil, err
+	}
+	count, err := r.Repository.CountBooks(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	return pg.NewBookConnection(page, items, count), nil
+}
+
+func (r *queryResolver) AuditLog(ctx context.Context, filter *AuditLogFilter, first *int, after *string) (*pg.AuditEntryConnection, error) {
+	page, err := pg.NewPage(pg.AuditOrder, first, after, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var f pg.AuditFilter
+	if filter != nil {
+		f = pg.AuditFilter{
+			Actor: filter.Actor,
+			Since: filter.Since,
+			Until: filter.Until,
+		}
+		if filter.Entity != nil {
+			entity := strings.ToLower(string(*filter.Entity))
+			f.Entity = &entity
+		}
+		if filter.EntityID != nil {
+			// the type of the ID implies the entity
+			typ, id, err := parseGlobalID(*filter.EntityID)
+			if err != nil {
+				return nil, err
+			}
+			entity := strings.ToLower(typ)
+			if entityTypes[entity] != typ || f.Entity != nil && *f.Entity != entity {
+				return nil, ErrInvalidID
+			}
+			f.Entity, f.EntityID = &entity, &id
+		}
+		if filter.Operation != nil {
+			op := strings.ToLower(string(*filter.Operation))
+			f.Op = &op
+		}
+	}
+	items, err := r.Repository.ListAuditEntries(ctx, f, page)
+	if err != nil {
+		return nil, err
+	}
+	return pg.NewAuditEntryConnection(page, items), nil
+}
+
+func (r *queryResolver) Search(ctx context.Context, query string, types []SearchType, first *int, after *string) (*pg.SearchConnection, error) {
+	page, err := pg.NewPage(pg.SearchOrder, first, after, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if types == nil {
+		types = AllSearchType
+	}
+	entities := make([]string, len(types))
+	for i, t := range types {
+		entities[i] = strings.ToLower(string(t))
+	}
+	return pg.SearchEntities(ctx, r.Repository, query, entities, page)
+}
+
+func (r *queryResolver) Node(ctx context.Context, id string) (pg.Node, error) {
+	nodes, err := r.Nodes(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+// Nodes loads the nodes of every type in a single batch.
+func (r *queryResolver) Nodes(ctx context.Context, ids []string) ([]pg.Node, error) {
+	var agentIDs, authorIDs, bookIDs []int64
+	types := make([]string, len(ids))
+	for i, gid := range ids {
+		typ, id, err := parseGlobalID(gid)
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case typeAgent:
+			agentIDs = append(agentIDs, id)
+		case typeAuthor:
+			authorIDs = append(authorIDs, id)
+		case typeBook:
+			bookIDs = append(bookIDs, id)
+		default:
+			return nil, ErrInvalidID
+		}
+		types[i] = typ
+	}
+	loaders := r.DataLoaders.Retrieve(ctx)
+	// the thunks are created first for the batches to be fetched concurrently
+	agentsThunk := loaders.AgentByID.LoadAllThunk(agentIDs)
+	authorsThunk := loaders.AuthorByID.LoadAllThunk(authorIDs)
+	booksThunk := loaders.BookByID.LoadAllThunk(bookIDs)
+	agents, errs := agentsThunk()
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+	authors, errs := authorsThunk()
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+	books, errs := booksThunk()
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+	nodes := make([]pg.Node, len(ids))
+	for i, typ := range types {
+		switch typ {
+		case typeAgent:
+			if agent := agents[0]; agent != nil && !agent.DeletedAt.Valid {
+				nodes[i] = *agent
+			}
+			agents = agents[1:]
+		case typeAuthor:
+			if author := authors[0]; author != nil && !author.DeletedAt.Valid {
+				nodes[i] = *author
+			}
+			authors = authors[1:]
+		case typeBook:
+			if book := books[0]; book != nil && !book.DeletedAt.Valid {
+				nodes[i] = *book
+			}
+			books = books[1:]
+		}
+	}
+	return nodes, nil
+}
+
+// firstError returns the first of the errors of a dataloader, if any.
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var agentOrders = map[AgentOrderBy]pg.Order{
+	AgentOrderByNameAsc:   {Field: pg.SortByName},
+	AgentOrderByNameDesc:  {Field: pg.SortByName, Desc: true},
+	AgentOrderByEmailAsc:  {Field: pg.SortByEmail},
+	AgentOrderByEmailDesc: {Field: pg.SortByEmail, Desc: true},
+	AgentOrderByIDAsc:     {Field: pg.SortByID},
+	AgentOrderByIDDesc:    {Field: pg.SortByID, Desc: true},
+}
+
+var authorOrders = map[AuthorOrderBy]pg.Order{
+	AuthorOrderByNameAsc:  {Field: pg.SortByName},
+	AuthorOrderByNameDesc: {Field: pg.SortByName, Desc: true},
+	AuthorOrderByIDAsc:    {Field: pg.SortByID},
+	AuthorOrderByIDDesc:   {Field: pg.SortByID, Desc: true},
+}
+
+var bookOrders = map[BookOrderBy]pg.Order{
+	BookOrderByTitleAsc:  {Field: pg.SortByTitle},
+	BookOrderByTitleDesc: {Field: pg.SortByTitle, Desc: true},
+	BookOrderByIDAsc:     {Field: pg.SortByID},
+	BookOrderByIDDesc:    {Field: pg.SortByID, Desc: true},
+}
+
+type subscriptionResolver struct{ *Resolver }
+
+func (r *subscriptionResolver) AgentChanged(ctx context.Context, gid *string) (<-chan *AgentChange, error) {
+	id, err := decodeOptionalID(typeAgent, gid)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan *AgentChange)
+	err = r.relay(ctx, topicAgentChanged, func(payload []byte) bool {
+		var event entityEvent
+		if json.Unmarshal(payload, &event) != nil || id != nil && event.ID != *id {
+			return true
+		}
+		// an entity purged in the meantime is skipped
+		agent, err := r.Repository.GetAgent(ctx, pg.GetAgentParams{ID: event.ID, IncludeDeleted: true})
+		if err != nil {
+			return true
+		}
+		select {
+		case ch <- &AgentChange{Action: event.Action, Agent: &agent}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch, err
+}
+
+func (r *subscriptionResolver) AuthorChanged(ctx context.Context, gid *string) (<-chan *AuthorChange, error) {
+	id, err := decodeOptionalID(typeAuthor, gid)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan *AuthorChange)
+	err = r.relay(ctx, topicAuthorChanged, func(payload []byte) bool {
+		var event entityEvent
+		if json.Unmarshal(payload, &event) != nil || id != nil && event.ID != *id {
+			return true
+		}
+		// an entity purged in the meantime is skipped
+		author, err := r.Repository.GetAuthor(ctx, pg.GetAuthorParams{ID: event.ID, IncludeDeleted: true})
+		if err != nil {
+			return true
+		}
+		select {
+		case ch <- &AuthorChange{Action: event.Action, Author: &author}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch, err
+}
+
+func (r *subscriptionResolver) BookChanged(ctx context.Context, gid *string) (<-chan *BookChange, error) {
+	id, err := decodeOptionalID(typeBook, gid)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan *BookChange)
+	err = r.relay(ctx, topicBookChanged, func(payload []byte) bool {
+		var event entityEvent
+		if json.Unmarshal(payload, &event) != nil || id != nil && event.ID != *id {
+			return true
+		}
+		// an entity purged in the meantime is skipped
+		book, err := r.Repository.GetBook(ctx, pg.GetBookParams{ID: event.ID, IncludeDeleted: true})
+		if err != nil {
+			return true
+		}
+		select {
+		case ch <- &BookChange{Action: event.Action, Book: &book}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch, err
+}
+
+func (r *subscriptionResolver) BookAddedForAuthor(ctx context.Context, gid string) (<-chan *pg.Book, error) {
+	authorID, err := decodeID(typeAuthor, gid)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan *pg.Book)
+	err = r.relay(ctx, topicBookAdded, func(payload []byte) bool {
+		var event bookAdded
+		if json.Unmarshal(payload, &event) != nil || event.AuthorID != authorID {
+			return true
+		}
+		book, err := r.Repository.GetBook(ctx, pg.GetBookParams{ID: event.BookID, IncludeDeleted: true})
+		if err != nil {
+			return true
+		}
+		select {
+		case ch <- &book:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}, func() { close(ch) })
+	return ch, err
 }
@@ -0,0 +1,109 @@
+package gqlgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
+	"github.com/vektah/gqlparser/ast"
+)
+
+// Broker delivers the events published by the mutations to the
+// subscriptions. It is implemented by memory.Broker within a single process
+// and by pg.Broker across all servers sharing a database.
+type Broker interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe returns a channel of the payloads published on topic, which
+	// is closed when ctx is done.
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
+
+// topics of the events published by the mutations
+const (
+	topicAgentChanged  = "agent_changed"
+	topicAuthorChanged = "author_changed"
+	topicBookChanged   = "book_changed"
+	topicBookAdded     = "book_added"
+)
+
+// entityEvent is published on the changed topics. It only identifies the
+// entity, which the subscribers load, so that the payloads stay within the
+// limits of the brokers whatever the size of the entity.
+type entityEvent struct {
+	Action ChangeAction `json:"action"`
+	ID     int64        `json:"id"`
+}
+
+// bookAdded is published for every author added to a book.
+type bookAdded struct {
+	BookID   int64 `json:"bookID"`
+	AuthorID int64 `json:"authorID"`
+}
+
+// publish sends event to the subscriptions. The mutation has been committed
+// at this point, so a failure is reported as an error of the response
+// without failing the mutation.
+func (r *Resolver) publish(ctx context.Context, topic string, event interface{}) {
+	payload, err := json.Marshal(event)
+	if err == nil {
+		err = r.Broker.Publish(ctx, topic, payload)
+	}
+	if err != nil {
+		graphql.AddError(ctx, fmt.Errorf("publishing %s event: %v", topic, err))
+	}
+}
+
+// relay subscribes to topic and passes the payloads to deliver in a new
+// goroutine, until the subscription ends or deliver returns false, and then
+// calls done. The entity is loaded in its current state, which may be more
+// recent than the change.
+func (r *subscriptionResolver) relay(ctx context.Context, topic string, deliver func(payload []byte) bool, done func()) error {
+	events, err := r.Broker.Subscribe(ctx, topic)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer done()
+		for payload := range events {
+			if !deliver(payload) {
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// subscriptionSchema adapts the executable schema to the way gqlgen runs the
+// request middlewares of subscriptions, which is once per event rather than
+// once per operation.
+type subscriptionSchema struct {
+	graphql.ExecutableSchema
+}
+
+// Subscription runs the request middlewares before subscribing, so that
+// they can reject the operation with an error, e.g. when it exceeds the
+// limits. It also clears the errors of every event, which would otherwise
+// be repeated in all the events that follow.
+//
+// The subscription gets dataloaders of its own, which are reset before every
+// event so that the entity of the event and its fields are loaded afresh.
+func (s subscriptionSchema) Subscription(ctx context.Context, op *ast.OperationDefinition) func() *graphql.Response {
+	ctx = dataloaders.ForSubscription(ctx)
+	rctx := graphql.GetRequestContext(ctx)
+	var next func() *graphql.Response
+	rctx.RequestMiddleware(ctx, func(ctx context.Context) []byte {
+		next = s.ExecutableSchema.Subscription(ctx, op)
+		return nil
+	})
+	if next == nil {
+		return graphql.OneShot(&graphql.Response{Data: []byte("null"), Errors: rctx.Errors})
+	}
+	return func() *graphql.Response {
+		// the previous event has been sent, no resolvers are running
+		rctx.Errors = nil
+		dataloaders.Reset(ctx)
+		return next()
+	}
+}
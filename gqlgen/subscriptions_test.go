@@ -0,0 +1,133 @@
+package gqlgen_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
+	"github.com/gorilla/websocket"
+)
+
+// wsMessage is a message of the graphql-ws protocol.
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// subscribe opens a websocket connection to srv and starts the subscriptions
+// of queries, by their ID.
+func subscribe(t *testing.T, srv *httptest.Server, queries map[string]string) *websocket.Conn {
+	t.Helper()
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-ws"}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.WriteJSON(wsMessage{Type: "connection_init"}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := readMessage(t, conn); msg.Type != "connection_ack" {
+		t.Fatalf("message %+v, want connection_ack", msg)
+	}
+	for id, query := range queries {
+		payload, _ := json.Marshal(map[string]string{"query": query})
+		if err := conn.WriteJSON(wsMessage{ID: id, Type: "start", Payload: payload}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the protocol does not acknowledge the subscriptions
+	time.Sleep(50 * time.Millisecond)
+	return conn
+}
+
+// readMessage returns the next message of conn other than the keep alives.
+func readMessage(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Type != "ka" {
+			return msg
+		}
+	}
+}
+
+// readEvent decodes the data of the next event of conn into data and returns
+// the ID of its subscription.
+func readEvent(t *testing.T, conn *websocket.Conn, data interface{}) string {
+	t.Helper()
+	msg := readMessage(t, conn)
+	var payload struct {
+		Data   json.RawMessage
+		Errors []responseError
+	}
+	if msg.Type != "data" || json.Unmarshal(msg.Payload, &payload) != nil || len(payload.Errors) > 0 {
+		t.Fatalf("message %s %s, want an event", msg.Type, msg.Payload)
+	}
+	if err := json.Unmarshal(payload.Data, data); err != nil {
+		t.Fatal(err)
+	}
+	return msg.ID
+}
+
+// post executes query against srv, failing on errors.
+func post(t *testing.T, srv *httptest.Server, query string) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"query": query})
+	res, err := http.Post(srv.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var out struct{ Errors []responseError }
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil || len(out.Errors) > 0 {
+		t.Fatalf("%s: %v %+v", query, err, out.Errors)
+	}
+}
+
+// The subscriptions of a connection have loaders of their own, reset before
+// every event, so that the events load the current state of their fields.
+func TestSubscriptionLoaders(t *testing.T) {
+	for _, adaptive := range []bool{false, true} {
+		h := as(admin, newLoadingHandler(t, dataloaders.Config{Wait: time.Millisecond, Adaptive: adaptive}))
+		srv := httptest.NewServer(h)
+		conn := subscribe(t, srv, map[string]string{
+			"book":   `subscription { bookChanged(id: "` + book1 + `") { book { title authors { edges { node { name } } } } } }`,
+			"author": `subscription { authorChanged(id: "` + author1 + `") { author { name } } }`,
+		})
+		for _, name := range []string{"Renamed", "Renamed again"} {
+			post(t, srv, `mutation { updateAuthor(id: "`+author1+`", data: {name: "`+name+`", agent_id: "`+agent1+`"}) { name } }`)
+			var author struct {
+				AuthorChanged struct{ Author struct{ Name string } }
+			}
+			if id := readEvent(t, conn, &author); id != "author" || author.AuthorChanged.Author.Name != name {
+				t.Errorf("adaptive %t: event %s %+v, want the author renamed %s", adaptive, id, author, name)
+			}
+			post(t, srv, `mutation { updateBook(id: "`+book1+`", data: {title: "`+name+`", description: "", cover: "", authorIDs: ["`+author1+`"]}) { title } }`)
+			var book struct {
+				BookChanged struct {
+					Book struct {
+						Title   string
+						Authors nameConnection
+					}
+				}
+			}
+			if id := readEvent(t, conn, &book); id != "book" || book.BookChanged.Book.Title != name || !equal(book.BookChanged.Book.Authors.names(), []string{name}) {
+				t.Errorf("adaptive %t: event %s %+v, want the book and its author renamed %s", adaptive, id, book, name)
+			}
+		}
+		conn.Close()
+		srv.Close()
+	}
+}
@@ -0,0 +1,26 @@
+package gqlgen
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+func TestVersion(t *testing.T) {
+	for _, v := range []int{math.MinInt32, 1, math.MaxInt32} {
+		got, err := version(&v)
+		if err != nil || got == nil || int(*got) != v {
+			t.Errorf("version(%d) = %v, %v", v, got, err)
+		}
+	}
+	for _, v := range []int{math.MinInt32 - 1, math.MaxInt32 + 1} {
+		if _, err := version(&v); !errors.Is(err, pg.ErrInvalidInput) {
+			t.Errorf("version(%d): got %v, want %v", v, err, pg.ErrInvalidInput)
+		}
+	}
+	if got, err := version(nil); got != nil || err != nil {
+		t.Errorf("version(nil) = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,125 @@
+// Package health provides the liveness and readiness endpoints of the server.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Statuses reported for the process and the individual checks.
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// CheckFunc reports whether a dependency of the server is usable.
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs the readiness checks of the server.
+type Checker struct {
+	timeout      time.Duration
+	checks       []check
+	shuttingDown int32
+}
+
+// NewChecker returns a Checker which gives each check at most timeout to
+// complete.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add registers a readiness check. It must not be called after the handlers
+// started serving requests.
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Shutdown makes the server report that it is not ready, so that load
+// balancers stop routing requests to it.
+func (c *Checker) Shutdown() {
+	atomic.StoreInt32(&c.shuttingDown, 1)
+}
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the body of the health endpoints.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Liveness returns the handler of /healthz, which reports that the process
+// is alive and serving requests.
+func (c *Checker) Liveness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, Report{Status: StatusOK})
+	})
+}
+
+// Readiness returns the handler of /readyz, which runs all checks
+// concurrently and reports 503 Service Unavailable if any of them fails or
+// the server is shutting down.
+func (c *Checker) Readiness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&c.shuttingDown) == 1 {
+			writeReport(w, Report{Status: StatusUnavailable})
+			return
+		}
+		writeReport(w, c.run(r.Context()))
+	})
+}
+
+func (c *Checker) run(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(c.checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, chk := range c.checks {
+		wg.Add(1)
+		go func(chk check) {
+			defer wg.Done()
+			start := time.Now()
+			err := chk.fn(ctx)
+			res := CheckResult{
+				Status:    StatusOK,
+				LatencyMS: float64(time.Since(start)) / float64(time.Millisecond),
+			}
+			if err != nil {
+				res.Status = StatusUnavailable
+				res.Error = err.Error()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[chk.name] = res
+			if err != nil {
+				report.Status = StatusUnavailable
+			}
+		}(chk)
+	}
+	wg.Wait()
+	return report
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status != StatusOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,124 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/health" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"     // update the username
+)
+
+// get serves a GET request with h and returns the status and the decoded
+// report.
+func get(t *testing.T, h http.Handler) (int, health.Report) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cc := w.Header().Get("Cache-Control"); cc != "no-store" {
+		t.Errorf("Cache-Control = %q", cc)
+	}
+	var report health.Report
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body, err)
+	}
+	return w.Code, report
+}
+
+// unreachableDSN returns the data source name of a closed port, whose
+// connections are refused.
+func unreachableDSN(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	return "host=127.0.0.1 port=" + strconv.Itoa(port) + " sslmode=disable connect_timeout=1"
+}
+
+func TestReadiness(t *testing.T) {
+	c := health.NewChecker(time.Second)
+	c.Add("cache", func(ctx context.Context) error { return nil })
+	code, report := get(t, c.Readiness())
+	if code != http.StatusOK || report.Status != health.StatusOK || report.Checks["cache"].Status != health.StatusOK {
+		t.Errorf("readiness %d %+v, want ok", code, report)
+	}
+}
+
+// A failing database makes the server not ready, but it is still alive.
+func TestFailingDatabase(t *testing.T) {
+	db, err := pg.Open(unreachableDSN(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	c := health.NewChecker(5 * time.Second)
+	c.Add("database", db.PingContext)
+	c.Add("cache", func(ctx context.Context) error { return nil })
+
+	code, report := get(t, c.Readiness())
+	if code != http.StatusServiceUnavailable || report.Status != health.StatusUnavailable {
+		t.Errorf("readiness %d %s, want %d %s", code, report.Status, http.StatusServiceUnavailable, health.StatusUnavailable)
+	}
+	if db := report.Checks["database"]; db.Status != health.StatusUnavailable || db.Error == "" {
+		t.Errorf("database check %+v, want unavailable with an error", db)
+	}
+	// the other checks are reported
+	if cache := report.Checks["cache"]; cache.Status != health.StatusOK || cache.Error != "" {
+		t.Errorf("cache check %+v, want ok", cache)
+	}
+
+	code, report = get(t, c.Liveness())
+	if code != http.StatusOK || report.Status != health.StatusOK || report.Checks != nil {
+		t.Errorf("liveness %d %+v, want ok without checks", code, report)
+	}
+}
+
+func TestReadinessTimeout(t *testing.T) {
+	c := health.NewChecker(10 * time.Millisecond)
+	c.Add("database", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	start := time.Now()
+	code, report := get(t, c.Readiness())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("readiness took %v, want the checks to time out", elapsed)
+	}
+	db := report.Checks["database"]
+	if code != http.StatusServiceUnavailable || db.Status != health.StatusUnavailable || db.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("readiness %d %+v, want the database check to time out", code, report)
+	}
+	if db.LatencyMS < 10 {
+		t.Errorf("latency = %vms, want at least the timeout", db.LatencyMS)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	c := health.NewChecker(time.Second)
+	called := false
+	c.Add("database", func(ctx context.Context) error {
+		called = true
+		return errors.New("not called")
+	})
+	c.Shutdown()
+	code, report := get(t, c.Readiness())
+	if code != http.StatusServiceUnavailable || report.Status != health.StatusUnavailable || called {
+		t.Errorf("readiness %d %+v, want unavailable without running the checks", code, report)
+	}
+	// the process is alive while it drains the requests
+	if code, report := get(t, c.Liveness()); code != http.StatusOK || report.Status != health.StatusOK {
+		t.Errorf("liveness %d %+v, want ok", code, report)
+	}
+}
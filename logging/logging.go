@@ -0,0 +1,106 @@
+// Package logging writes structured JSON logs and instruments the request
+// path with request IDs, per-operation logs and slow query reports.
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log entry.
+type Level int
+
+// Log levels, in increasing severity.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = [...]string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel returns the level with the given name.
+func ParseLevel(name string) (Level, error) {
+	for i, n := range levelNames {
+		if n == name {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("logging: unknown level %q", name)
+}
+
+// Logger writes one JSON object per line. It is safe for concurrent use.
+type Logger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level Level
+}
+
+// New returns a Logger writing entries of at least level to w.
+func New(w io.Writer, level Level) *Logger {
+	return &Logger{w: w, level: level}
+}
+
+// Debug logs msg with the key-value pairs kv at debug level.
+func (l *Logger) Debug(msg string, kv ...interface{}) {
+	l.log(LevelDebug, msg, kv)
+}
+
+// Info logs msg with the key-value pairs kv at info level.
+func (l *Logger) Info(msg string, kv ...interface{}) {
+	l.log(LevelInfo, msg, kv)
+}
+
+// Warn logs msg with the key-value pairs kv at warn level.
+func (l *Logger) Warn(msg string, kv ...interface{}) {
+	l.log(LevelWarn, msg, kv)
+}
+
+// Error logs msg with the key-value pairs kv at error level.
+func (l *Logger) Error(msg string, kv ...interface{}) {
+	l.log(LevelError, msg, kv)
+}
+
+func (l *Logger) log(level Level, msg string, kv []interface{}) {
+	if level < l.level {
+		return
+	}
+	buf := []byte(`{"time":`)
+	buf = appendJSON(buf, time.Now().UTC().Format(time.RFC3339Nano))
+	buf = append(buf, `,"level":`...)
+	buf = appendJSON(buf, level.String())
+	buf = append(buf, `,"msg":`...)
+	buf = appendJSON(buf, msg)
+	for i := 0; i+1 < len(kv); i += 2 {
+		buf = append(buf, ',')
+		buf = appendJSON(buf, fmt.Sprint(kv[i]))
+		buf = append(buf, ':')
+		buf = appendJSON(buf, kv[i+1])
+	}
+	buf = append(buf, "}\n"...)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.w.Write(buf)
+}
+
+func appendJSON(buf []byte, v interface{}) []byte {
+	switch x := v.(type) {
+	case error:
+		v = x.Error()
+	case time.Duration:
+		v = float64(x) / float64(time.Millisecond)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(fmt.Sprint(v))
+	}
+	return append(buf, b...)
+}
@@ -0,0 +1,214 @@
+package logging_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/auth"        // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/logging"     // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/memory"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"          // update the username
+)
+
+// entries decodes the JSON lines written to buf.
+func entries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	s := bufio.NewScanner(buf)
+	for s.Scan() {
+		var e map[string]interface{}
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatalf("line %q: %v", s.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := logging.New(&buf, logging.LevelInfo)
+	l.Debug("hidden")
+	l.Info("shown", "duration_ms", 1500*time.Microsecond, "err", errors.New("failed"), "count", 3)
+	l.Error("odd", "key")
+	got := entries(t, &buf)
+	if len(got) != 2 {
+		t.Fatalf("entries %v, want the info and error entries", got)
+	}
+	e := got[0]
+	if e["level"] != "info" || e["msg"] != "shown" || e["duration_ms"] != 1.5 || e["err"] != "failed" || e["count"] != float64(3) {
+		t.Errorf("entry %v", e)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, e["time"].(string)); err != nil {
+		t.Errorf("time: %v", err)
+	}
+	// a key without a value is dropped
+	if e := got[1]; e["level"] != "error" || len(e) != 3 {
+		t.Errorf("entry %v, want time, level and msg", e)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	var id string
+	h := logging.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id = logging.RequestIDFromContext(r.Context())
+	}))
+	generated := regexp.MustCompile(`^[0-9a-f]{32}$`)
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"given", "abc-123", "abc-123"},
+		{"missing", "", ""},
+		{"control characters", "abc\x01", ""},
+		{"spaces", "abc 123", ""},
+		{"too long", strings.Repeat("a", 129), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(logging.RequestIDHeader, tt.header)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if tt.want != "" && id != tt.want || tt.want == "" && !generated.MatchString(id) {
+				t.Errorf("request ID = %q, want %q or a generated one", id, tt.want)
+			}
+			if got := w.Header().Get(logging.RequestIDHeader); got != id {
+				t.Errorf("%s = %q, want %q", logging.RequestIDHeader, got, id)
+			}
+		})
+	}
+	if id := logging.RequestIDFromContext(context.Background()); id != "" {
+		t.Errorf("RequestIDFromContext() = %q outside of a request", id)
+	}
+}
+
+// The operations are logged with the ID of their request.
+func TestOperationLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.LevelInfo)
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	agent, err := repo.CreateAgent(ctx, pg.CreateAgentParams{Name: "Agent", Email: "agent@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateAuthor(ctx, pg.CreateAuthorParams{Name: "Author", AgentID: agent.ID}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := dataloaders.Config{Wait: time.Millisecond, Observer: logging.BatchCounter{}}
+	options := append(cfg.HandlerOptions(gqlgen.Schema()), logger.HandlerOptions()...)
+	h := logging.RequestID(dataloaders.Middleware(repo, cfg)(
+		gqlgen.NewHandler(repo, dataloaders.NewRetriever(), memory.NewBroker(), gqlgen.Limits{}, options...),
+	))
+	admin := &auth.Principal{Subject: "admin", Roles: []auth.Role{auth.RoleAdmin}}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"query":     `query ListAgents($first: Int, $filter: AgentFilter) { agents(first: $first, filter: $filter) { edges { node { authors { edges { node { name } } } } } } }`,
+		"variables": map[string]interface{}{"first": 10, "filter": map[string]interface{}{"emailDomain": "example.com"}},
+	})
+	r := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(logging.RequestIDHeader, "request-1")
+	r = r.WithContext(auth.WithPrincipal(r.Context(), admin))
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	got := entries(t, &buf)
+	if len(got) != 1 {
+		t.Fatalf("entries %v, want one", got)
+	}
+	e := got[0]
+	if e["level"] != "info" || e["msg"] != "graphql operation" || e["request_id"] != "request-1" || e["operation"] != "ListAgents" {
+		t.Errorf("entry %v, want the operation ListAgents of request-1", e)
+	}
+	if e["errors"] != float64(0) {
+		t.Errorf("errors = %v, want 0", e["errors"])
+	}
+	// the authors of the agents are loaded in a batch
+	if e["dataloader_batches"] != float64(1) {
+		t.Errorf("dataloader_batches = %v, want 1", e["dataloader_batches"])
+	}
+	if d, ok := e["duration_ms"].(float64); !ok || d <= 0 {
+		t.Errorf("duration_ms = %v, want a positive number", e["duration_ms"])
+	}
+	vars, _ := json.Marshal(e["variables"])
+	if want := `{"filter":{"emailDomain":"[REDACTED]"},"first":10}`; string(vars) != want {
+		t.Errorf("variables = %s, want %s", vars, want)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	vars := map[string]interface{}{
+		"password": "p",
+		"input": map[string]interface{}{
+			"name":     "Name",
+			"apiKey":   "k",
+			"contacts": []interface{}{map[string]interface{}{"Email": "e"}, "other"},
+		},
+	}
+	got, _ := json.Marshal(logging.Redact(vars))
+	want := `{"input":{"apiKey":"[REDACTED]","contacts":[{"Email":"[REDACTED]"},"other"],"name":"Name"},"password":"[REDACTED]"}`
+	if string(got) != want {
+		t.Errorf("Redact() = %s, want %s", got, want)
+	}
+	// the variables are not modified
+	if vars["password"] != "p" {
+		t.Error("Redact() modified the variables")
+	}
+	if logging.Redact(nil) != nil {
+		t.Error("Redact(nil) != nil")
+	}
+}
+
+// execDBTX is a pg.DBTX which only executes statements.
+type execDBTX struct {
+	pg.DBTX
+	delay time.Duration
+}
+
+func (d execDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	time.Sleep(d.delay)
+	return nil, nil
+}
+
+func TestSlowQueries(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, logging.LevelInfo)
+	var requestCtx context.Context
+	logging.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCtx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	fast := logger.SlowQueries(time.Hour)(execDBTX{})
+	fast.ExecContext(requestCtx, "-- name: DeleteAgent :exec\nDELETE FROM agents WHERE id = $1", 1)
+	slow := logger.SlowQueries(time.Millisecond)(execDBTX{delay: 2 * time.Millisecond})
+	slow.ExecContext(requestCtx, "-- name: DeleteAgent :exec\nDELETE FROM agents WHERE id = $1", 1)
+
+	got := entries(t, &buf)
+	if len(got) != 1 {
+		t.Fatalf("entries %v, want the slow query", got)
+	}
+	e := got[0]
+	if e["level"] != "warn" || e["msg"] != "slow query" || e["query"] != "DeleteAgent" || e["threshold_ms"] != float64(1) {
+		t.Errorf("entry %v, want DeleteAgent over 1ms", e)
+	}
+	if e["request_id"] != logging.RequestIDFromContext(requestCtx) || e["request_id"] == "" {
+		t.Errorf("request_id = %v, want %s", e["request_id"], logging.RequestIDFromContext(requestCtx))
+	}
+	if d, ok := e["duration_ms"].(float64); !ok || d < 2 {
+		t.Errorf("duration_ms = %v, want at least 2", e["duration_ms"])
+	}
+}
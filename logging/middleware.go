@@ -0,0 +1,200 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/handler"
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"     // update the username
+)
+
+// RequestIDHeader is the header carrying the request ID.
+const RequestIDHeader = "X-Request-ID"
+
+type contextKey string
+
+const requestKey = contextKey("request")
+
+// request holds the per-request state shared by the instrumentation.
+type request struct {
+	id      string
+	batches int64
+}
+
+// RequestID returns a middleware assigning every request an ID, which is
+// taken from the X-Request-ID header if the client sent a valid one. The ID
+// is echoed in the response header and included in the logs of the request.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestKey, &request{id: id})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the ID of the request, or "" outside of a
+// request.
+func RequestIDFromContext(ctx context.Context) string {
+	if req, ok := ctx.Value(requestKey).(*request); ok {
+		return req.id
+	}
+	return ""
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// HandlerOptions returns the options of the GraphQL handler logging one line
+// per operation.
+func (l *Logger) HandlerOptions() []handler.Option {
+	return []handler.Option{handler.RequestMiddleware(l.logOperation)}
+}
+
+func (l *Logger) logOperation(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+	start := time.Now()
+	res := next(ctx)
+	rctx := graphql.GetRequestContext(ctx)
+	var batches int64
+	if req, ok := ctx.Value(requestKey).(*request); ok {
+		batches = atomic.LoadInt64(&req.batches)
+	}
+	l.Info("graphql operation",
+		"request_id", RequestIDFromContext(ctx),
+		"operation", gqlgen.OperationName(rctx),
+		"variables", Redact(rctx.Variables),
+		"duration_ms", time.Since(start),
+		"errors", len(rctx.Errors),
+		"dataloader_batches", batches,
+	)
+	return res
+}
+
+// sensitiveKeys are the parts of variable names whose values are redacted.
+var sensitiveKeys = []string{"password", "secret", "token", "apikey", "api_key", "authorization", "email"}
+
+// Redact returns a copy of the variables with the values of sensitive keys
+// replaced, looking into nested objects and lists.
+func Redact(vars map[string]interface{}) map[string]interface{} {
+	if vars == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(vars))
+	for k, v := range vars {
+		if isSensitive(k) {
+			out[k] = "[REDACTED]"
+			continue
+		}
+		out[k] = redactValue(v)
+	}
+	return out
+}
+
+func redactValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		return Redact(x)
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, e := range x {
+			out[i] = redactValue(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// BatchCounter counts the dataloader batches issued by each request for the
+// operation log. It implements dataloaders.BatchObserver.
+type BatchCounter struct{}
+
+// StartBatch implements dataloaders.BatchObserver.
+func (BatchCounter) StartBatch(ctx context.Context, loader string, keys int) (context.Context, func(error)) {
+	if req, ok := ctx.Value(requestKey).(*request); ok {
+		atomic.AddInt64(&req.batches, 1)
+	}
+	return ctx, func(error) {}
+}
+
+// SlowQueries returns a pg.DBTXMiddleware logging every statement which takes
+// longer than threshold, together with its name from queries.sql.
+func (l *Logger) SlowQueries(threshold time.Duration) pg.DBTXMiddleware {
+	return func(next pg.DBTX) pg.DBTX {
+		return &slowQueryDBTX{next: next, l: l, threshold: threshold}
+	}
+}
+
+type slowQueryDBTX struct {
+	next      pg.DBTX
+	l         *Logger
+	threshold time.Duration
+}
+
+func (d *slowQueryDBTX) observe(ctx context.Context, query string, start time.Time) {
+	if elapsed := time.Since(start); elapsed > d.threshold {
+		d.l.Warn("slow query",
+			"request_id", RequestIDFromContext(ctx),
+			"query", pg.QueryName(query),
+			"duration_ms", elapsed,
+			"threshold_ms", d.threshold,
+		)
+	}
+}
+
+func (d *slowQueryDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer d.observe(ctx, query, time.Now())
+	return d.next.ExecContext(ctx, query, args...)
+}
+
+func (d *slowQueryDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.next.PrepareContext(ctx, query)
+}
+
+func (d *slowQueryDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer d.observe(ctx, query, time.Now())
+	return d.next.QueryContext(ctx, query, args...)
+}
+
+func (d *slowQueryDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	defer d.observe(ctx, query, time.Now())
+	return d.next.QueryRowContext(ctx, query, args...)
+}
@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer is the number of events a slow subscriber may fall
+// behind before further events are dropped for it.
+const subscriberBuffer = 64
+
+// Broker delivers events to the subscribers within the process. It is safe
+// for concurrent use.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[string]map[chan []byte]struct{}
+}
+
+// NewBroker returns an in-process Broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[string]map[chan []byte]struct{})}
+}
+
+// Publish delivers payload to the current subscribers of topic. It never
+// blocks: subscribers which are too far behind miss the event.
+func (b *Broker) Publish(ctx context.Context, topic string, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a channel of the payloads published on topic, which is
+// closed when ctx is done.
+func (b *Broker) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriberBuffer)
+	b.mu.Lock()
+	if b.subs[topic] == nil {
+		b.subs[topic] = make(map[chan []byte]struct{})
+	}
+	b.subs[topic][ch] = struct{}{}
+	b.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs[topic], ch)
+		if len(b.subs[topic]) == 0 {
+			delete(b.subs, topic)
+		}
+		close(ch)
+	}()
+	return ch, nil
+}
@@ -0,0 +1,202 @@
+package memory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+func matchAgent(f pg.AgentFilter, a pg.Agent) bool {
+	if a.DeletedAt.Valid && !f.IncludeDeleted {
+		return false
+	}
+	if f.NameContains != nil && !containsFold(a.Name, *f.NameContains) {
+		return false
+	}
+	if f.EmailDomain != nil && !strings.EqualFold(emailDomain(a.Email), *f.EmailDomain) {
+		return false
+	}
+	return true
+}
+
+func matchAuthor(f pg.AuthorFilter, a pg.Author) bool {
+	if a.DeletedAt.Valid && !f.IncludeDeleted {
+		return false
+	}
+	if f.NameContains != nil && !containsFold(a.Name, *f.NameContains) {
+		return false
+	}
+	if f.HasWebsite != nil && a.Website.Valid != *f.HasWebsite {
+		return false
+	}
+	if f.AgentIDs != nil && !idSet(f.AgentIDs)[a.AgentID] {
+		return false
+	}
+	return true
+}
+
+// matchBook must be called with the read lock held.
+func (r *repoSvc) matchBook(f pg.BookFilter, b pg.Book) bool {
+	if b.DeletedAt.Valid && !f.IncludeDeleted {
+		return false
+	}
+	if f.TitleContains != nil && !containsFold(b.Title, *f.TitleContains) {
+		return false
+	}
+	if f.DescriptionContains != nil && !containsFold(b.Description, *f.DescriptionContains) {
+		return false
+	}
+	if f.AuthorIDs != nil {
+		ids := idSet(f.AuthorIDs)
+		for _, ba := range r.bookAuthors {
+			if ba.BookID == b.ID && ids[ba.AuthorID] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchAuditEntry(f pg.AuditFilter, e pg.AuditEntry) bool {
+	if f.Entity != nil && e.Entity != *f.Entity {
+		return false
+	}
+	if f.EntityID != nil && e.EntityID != *f.EntityID {
+		return false
+	}
+	if f.Op != nil && e.Op != *f.Op {
+		return false
+	}
+	if f.Actor != nil && (!e.Actor.Valid || e.Actor.String != *f.Actor) {
+		return false
+	}
+	if f.Since != nil && e.ChangedAt.Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && !e.ChangedAt.Before(*f.Until) {
+		return false
+	}
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// emailDomain mirrors split_part(email, '@', 2).
+func emailDomain(email string) string {
+	parts := strings.Split(email, "@")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// The sort key functions return the cursor key of an item for a sort field.
+// Sorting by id uses an empty key so that only ids are compared.
+
+func agentSortKey(f pg.SortField) (func(pg.Agent) string, error) {
+	switch f {
+	case "", pg.SortByName:
+		return func(a pg.Agent) string { return a.Name }, nil
+	case pg.SortByEmail:
+		return func(a pg.Agent) string { return a.Email }, nil
+	case pg.SortByID:
+		return func(pg.Agent) string { return "" }, nil
+	}
+	return nil, fmt.Errorf("memory: cannot sort agents by %q", f)
+}
+
+func authorSortKey(f pg.SortField) (func(pg.Author) string, error) {
+	switch f {
+	case "", pg.SortByName:
+		return func(a pg.Author) string { return a.Name }, nil
+	case pg.SortByID:
+		return func(pg.Author) string { return "" }, nil
+	}
+	return nil, fmt.Errorf("memory: cannot sort authors by %q", f)
+}
+
+func bookSortKey(f pg.SortField) (func(pg.Book) string, error) {
+	switch f {
+	case "", pg.SortByTitle:
+		return func(b pg.Book) string { return b.Title }, nil
+	case pg.SortByID:
+		return func(pg.Book) string { return "" }, nil
+	}
+	return nil, fmt.Errorf("memory: cannot sort books by %q", f)
+}
+
+// keyset holds the pagination arguments shared by all page queries.
+type keyset struct {
+	HasAfter  bool
+	AfterKey  string
+	AfterID   int64
+	HasBefore bool
+	BeforeKey string
+	BeforeID  int64
+	Desc      bool
+	Reverse   bool
+	RowLimit  int32
+}
+
+func pageKeyset(page pg.Page) keyset {
+	return keyset{
+		HasAfter:  page.HasAfter,
+		AfterKey:  page.After.Key,
+		AfterID:   page.After.ID,
+		HasBefore: page.HasBefore,
+		BeforeKey: page.Before.Key,
+		BeforeID:  page.Before.ID,
+		Desc:      page.Order.Desc,
+		Reverse:   page.Reverse,
+		RowLimit:  int32(page.Limit + 1),
+	}
+}
+
+// match reports whether the row with the given sort key and id lies between
+// the after and before cursors.
+func (k keyset) match(key string, id int64) bool {
+	if k.HasAfter && !k.follows(key, id, k.AfterKey, k.AfterID) {
+		return false
+	}
+	if k.HasBefore && !k.follows(k.BeforeKey, k.BeforeID, key, id) {
+		return false
+	}
+	return true
+}
+
+// follows reports whether row a comes after row b in the sort order.
+func (k keyset) follows(ka string, ia int64, kb string, ib int64) bool {
+	if k.Desc {
+		return greater(kb, ib, ka, ia)
+	}
+	return greater(ka, ia, kb, ib)
+}
+
+// less orders rows in the order they are returned by a page query: the sort
+// order, reversed for backward pages.
+func (k keyset) less(ki string, ii int64, kj string, ij int64) bool {
+	if k.Reverse {
+		return k.follows(ki, ii, kj, ij)
+	}
+	return k.follows(kj, ij, ki, ii)
+}
+
+// limit returns the number of rows of n that fit in the page.
+func (k keyset) limit(n int) int {
+	if n > int(k.RowLimit) {
+		return int(k.RowLimit)
+	}
+	return n
+}
+
+// greater implements the (key, id) > (key, id) row comparison.
+func greater(ka string, ia int64, kb string, ib int64) bool {
+	if ka != kb {
+		return ka > kb
+	}
+	return ia > ib
+}
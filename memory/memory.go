@@ -0,0 +1,936 @@
+// Package memory provides an in-memory implementation of pg.Repository.
+//
+// It is intended for tests and local development where running a PostgreSQL
+// instance is impractical. It mirrors the constraints declared in the pg
+// migrations and reports violations using the same errors as the postgres
+// repository.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+type repoSvc struct {
+	mu sync.RWMutex
+
+	agents      map[int64]pg.Agent
+	authors     map[int64]pg.Author
+	books       map[int64]pg.Book
+	bookAuthors []pg.BookAuthor
+	outbox      []pg.OutboxEvent
+	audit       []pg.AuditEntry
+
+	// sequences emulating the BIGSERIAL columns
+	agentSeq      int64
+	authorSeq     int64
+	bookSeq       int64
+	bookAuthorSeq int64
+	outboxSeq     int64
+	auditSeq      int64
+}
+
+// NewRepository returns an in-memory implementation of the pg.Repository
+// interface. It is safe for concurrent use.
+func NewRepository() pg.Repository {
+	return &repoSvc{
+		agents:  make(map[int64]pg.Agent),
+		authors: make(map[int64]pg.Author),
+		books:   make(map[int64]pg.Book),
+	}
+}
+
+// agent queries
+
+func (r *repoSvc) CreateAgent(ctx context.Context, arg pg.CreateAgentParams) (pg.Agent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.agentSeq++
+	agent := pg.Agent{
+		ID:      r.agentSeq,
+		Name:    arg.Name,
+		Email:   arg.Email,
+		Version: 1,
+	}
+	r.agents[agent.ID] = agent
+	r.record(pg.NewAgentChange(ctx, pg.OpCreate, nil, &agent))
+	return agent, nil
+}
+
+func (r *repoSvc) DeleteAgent(ctx context.Context, id int64) (pg.Agent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	agent, ok := r.agents[id]
+	if !ok || agent.DeletedAt.Valid {
+		return pg.Agent{}, pg.ErrNotFound
+	}
+	// deleted authors keep referencing the agent
+	for _, author := range r.authors {
+		if author.AgentID == id && !author.DeletedAt.Valid {
+			return pg.Agent{}, &pg.ConstraintError{
+				Err:        pg.ErrConflict,
+				Message:    "update or delete on table \"agents\" violates foreign key constraint \"authors_agent_id_fkey\" on table \"authors\"",
+				Detail:     fmt.Sprintf("Key (id)=(%d) is still referenced from table \"authors\".", id),
+				Constraint: "authors_agent_id_fkey",
+			}
+		}
+	}
+	agent.DeletedAt = deletedNow()
+	agent.Version++
+	r.agents[id] = agent
+	r.record(pg.NewAgentChange(ctx, pg.OpDelete, &agent, nil))
+	return agent, nil
+}
+
+func (r *repoSvc) RestoreAgent(ctx context.Context, id int64) (pg.Agent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	agent, ok := r.agents[id]
+	if !ok || !agent.DeletedAt.Valid {
+		return pg.Agent{}, pg.ErrNotFound
+	}
+	agent.DeletedAt = sql.NullTime{}
+	agent.Version++
+	r.agents[id] = agent
+	r.record(pg.NewAgentChange(ctx, pg.OpRestore, nil, &agent))
+	return agent, nil
+}
+
+func (r *repoSvc) GetAgent(ctx context.Context, arg pg.GetAgentParams) (pg.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agent, ok := r.agents[arg.ID]
+	if !ok || agent.DeletedAt.Valid && !arg.IncludeDeleted {
+		return pg.Agent{}, pg.ErrNotFound
+	}
+	return agent, nil
+}
+
+func (r *repoSvc) ListAgents(ctx context.Context, filter pg.AgentFilter, page pg.Page) ([]pg.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, err := agentSortKey(page.Order.Field)
+	if err != nil {
+		return nil, err
+	}
+	ks := pageKeyset(page)
+	var items []pg.Agent
+	for _, agent := range r.agents {
+		if matchAgent(filter, agent) && ks.match(key(agent), agent.ID) {
+			items = append(items, agent)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return ks.less(key(items[i]), items[i].ID, key(items[j]), items[j].ID)
+	})
+	return items[:ks.limit(len(items))], nil
+}
+
+func (r *repoSvc) CountAgents(ctx context.Context, filter pg.AgentFilter) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var count int64
+	for _, agent := range r.agents {
+		if matchAgent(filter, agent) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *repoSvc) UpdateAgent(ctx context.Context, arg pg.UpdateAgentParams, expectedVersion *int32) (pg.Agent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	before, ok := r.agents[arg.ID]
+	if !ok || before.DeletedAt.Valid {
+		return pg.Agent{}, pg.ErrNotFound
+	}
+	if err := checkVersion(pg.EntityAgent, arg.ID, expectedVersion, before.Version); err != nil {
+		return pg.Agent{}, err
+	}
+	agent := pg.Agent{
+		ID:      arg.ID,
+		Name:    arg.Name,
+		Email:   arg.Email,
+		Version: before.Version + 1,
+	}
+	r.agents[agent.ID] = agent
+	r.record(pg.NewAgentChange(ctx, pg.OpUpdate, &before, &agent))
+	return agent, nil
+}
+
+func (r *repoSvc) ListAgentsByAuthorIDs(ctx context.Context, authorIDs []int64) ([]pg.ListAgentsByAuthorIDsRow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var items []pg.ListAgentsByAuthorIDsRow
+	for _, authorID := range uniqueIDs(authorIDs) {
+		author, ok := r.authors[authorID]
+		if !ok {
+			continue
+		}
+		agent := r.agents[author.AgentID]
+		items = append(items, pg.ListAgentsByAuthorIDsRow{
+			ID:        agent.ID,
+			Name:      agent.Name,
+			Email:     agent.Email,
+			DeletedAt: agent.DeletedAt,
+			Version:   agent.Version,
+			AuthorID:  author.ID,
+		})
+	}
+	return items, nil
+}
+
+func (r *repoSvc) ListAgentsByIDs(ctx context.Context, ids []int64) ([]pg.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var items []pg.Agent
+	for _, id := range ids {
+		if agent, ok := r.agents[id]; ok {
+			items = append(items, agent)
+		}
+	}
+	return items, nil
+}
+
+// author queries
+
+func (r *repoSvc) CreateAuthor(ctx context.Context, arg pg.CreateAuthorParams) (pg.Author, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.checkAgentRef(arg.AgentID); err != nil {
+		return pg.Author{}, err
+	}
+	r.authorSeq++
+	author := pg.Author{
+		ID:      r.authorSeq,
+		Name:    arg.Name,
+		Website: arg.Website,
+		AgentID: arg.AgentID,
+		Version: 1,
+	}
+	r.authors[author.ID] = author
+	r.record(pg.NewAuthorChange(ctx, pg.OpCreate, nil, &author))
+	return author, nil
+}
+
+func (r *repoSvc) DeleteAuthor(ctx context.Context, id int64) (pg.Author, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	author, ok := r.authors[id]
+	if !ok || author.DeletedAt.Valid {
+		return pg.Author{}, pg.ErrNotFound
+	}
+	author.DeletedAt = deletedNow()
+	author.Version++
+	r.authors[id] = author
+	r.record(pg.NewAuthorChange(ctx, pg.OpDelete, &author, nil))
+	return author, nil
+}
+
+func (r *repoSvc) RestoreAuthor(ctx context.Context, id int64) (pg.Author, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	author, ok := r.authors[id]
+	if !ok || !author.DeletedAt.Valid {
+		return pg.Author{}, pg.ErrNotFound
+	}
+	if err := r.checkAgentRef(author.AgentID); err != nil {
+		return pg.Author{}, err
+	}
+	author.DeletedAt = sql.NullTime{}
+	author.Version++
+	r.authors[id] = author
+	r.record(pg.NewAuthorChange(ctx, pg.OpRestore, nil, &author))
+	return author, nil
+}
+
+func (r *repoSvc) GetAuthor(ctx context.Context, arg pg.GetAuthorParams) (pg.Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	author, ok := r.authors[arg.ID]
+	if !ok || author.DeletedAt.Valid && !arg.IncludeDeleted {
+		return pg.Author{}, pg.ErrNotFound
+	}
+	return author, nil
+}
+
+func (r *repoSvc) ListAuthors(ctx context.Context, filter pg.AuthorFilter, page pg.Page) ([]pg.Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, err := authorSortKey(page.Order.Field)
+	if err != nil {
+		return nil, err
+	}
+	ks := pageKeyset(page)
+	var items []pg.Author
+	for _, author := range r.authors {
+		if matchAuthor(filter, author) && ks.match(key(author), author.ID) {
+			items = append(items, author)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return ks.less(key(items[i]), items[i].ID, key(items[j]), items[j].ID)
+	})
+	return items[:ks.limit(len(items))], nil
+}
+
+func (r *repoSvc) CountAuthors(ctx context.Context, filter pg.AuthorFilter) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var count int64
+	for _, author := range r.authors {
+		if matchAuthor(filter, author) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *repoSvc) UpdateAuthor(ctx context.Context, arg pg.UpdateAuthorParams, expectedVersion *int32) (pg.Author, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	before, ok := r.authors[arg.ID]
+	if !ok || before.DeletedAt.Valid {
+		return pg.Author{}, pg.ErrNotFound
+	}
+	if err := checkVersion(pg.EntityAuthor, arg.ID, expectedVersion, before.Version); err != nil {
+		return pg.Author{}, err
+	}
+	if err := r.checkAgentRef(arg.AgentID); err != nil {
+		return pg.Author{}, err
+	}
+	author := pg.Author{
+		ID:      arg.ID,
+		Name:    arg.Name,
+		Website: arg.Website,
+		AgentID: arg.AgentID,
+		Version: before.Version + 1,
+	}
+	r.authors[author.ID] = author
+	r.record(pg.NewAuthorChange(ctx, pg.OpUpdate, &before, &author))
+	return author, nil
+}
+
+func (r *repoSvc) ListAuthorsByAgentIDsPage(ctx context.Context, arg pg.ListAuthorsByAgentIDsPageParams) ([]pg.ListAuthorsByAgentIDsPageRow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ks := keyset{
+		HasAfter:  arg.HasAfter,
+		AfterKey:  arg.AfterKey,
+		AfterID:   arg.AfterID,
+		HasBefore: arg.HasBefore,
+		BeforeKey: arg.BeforeKey,
+		BeforeID:  arg.BeforeID,
+		Reverse:   arg.Reverse,
+		RowLimit:  arg.RowLimit,
+	}
+	ids := idSet(arg.AgentIds)
+	var items []pg.ListAuthorsByAgentIDsPageRow
+	for _, author := range r.authors {
+		if ids[author.AgentID] && !author.DeletedAt.Valid && ks.match(author.Name, author.ID) {
+			items = append(items, pg.ListAuthorsByAgentIDsPageRow{
+				ID:      author.ID,
+				Name:    author.Name,
+				Website: author.Website,
+				AgentID: author.AgentID,
+				Version: author.Version,
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].AgentID != items[j].AgentID {
+			return items[i].AgentID < items[j].AgentID
+		}
+		return ks.less(items[i].Name, items[i].ID, items[j].Name, items[j].ID)
+	})
+	var page []pg.ListAuthorsByAgentIDsPageRow
+	for n, i := 0, 0; i < len(items); i++ {
+		if i > 0 && items[i].AgentID != items[i-1].AgentID {
+			n = 0
+		}
+		if n++; n <= int(ks.RowLimit) {
+			page = append(page, items[i])
+		}
+	}
+	return page, nil
+}
+
+func (r *repoSvc) CountAuthorsByAgentIDs(ctx context.Context, agentIDs []int64) ([]pg.CountAuthorsByAgentIDsRow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := idSet(agentIDs)
+	counts := make(map[int64]int64)
+	for _, author := range r.authors {
+		if ids[author.AgentID] && !author.DeletedAt.Valid {
+			counts[author.AgentID]++
+		}
+	}
+	var items []pg.CountAuthorsByAgentIDsRow
+	for agentID, count := range counts {
+		items = append(items, pg.CountAuthorsByAgentIDsRow{AgentID: agentID, Count: count})
+	}
+	return items, nil
+}
+
+func (r *repoSvc) ListAuthorsByBookIDsPage(ctx context.Context, arg pg.ListAuthorsByBookIDsPageParams) ([]pg.ListAuthorsByBookIDsPageRow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ks := keyset{
+		HasAfter:  arg.HasAfter,
+		AfterKey:  arg.AfterKey,
+		AfterID:   arg.AfterID,
+		HasBefore: arg.HasBefore,
+		BeforeKey: arg.BeforeKey,
+		BeforeID:  arg.BeforeID,
+		Reverse:   arg.Reverse,
+		RowLimit:  arg.RowLimit,
+	}
+	ids := idSet(arg.BookIds)
+	var items []pg.ListAuthorsByBookIDsPageRow
+	for _, ba := range r.bookAuthors {
+		author := r.authors[ba.AuthorID]
+		if ids[ba.BookID] && !author.DeletedAt.Valid && ks.match(author.Name, author.ID) {
+			items = append(items, pg.ListAuthorsByBookIDsPageRow{
+				ID:      author.ID,
+				Name:    author.Name,
+				Website: author.Website,
+				AgentID: author.AgentID,
+				Version: author.Version,
+				BookID:  ba.BookID,
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].BookID != items[j].BookID {
+			return items[i].BookID < items[j].BookID
+		}
+		return ks.less(items[i].Name, items[i].ID, items[j].Name, items[j].ID)
+	})
+	var page []pg.ListAuthorsByBookIDsPageRow
+	for n, i := 0, 0; i < len(items); i++ {
+		if i > 0 && items[i].BookID != items[i-1].BookID {
+			n = 0
+		}
+		if n++; n <= int(ks.RowLimit) {
+			page = append(page, items[i])
+		}
+	}
+	return page, nil
+}
+
+func (r *repoSvc) CountAuthorsByBookIDs(ctx context.Context, bookIDs []int64) ([]pg.CountAuthorsByBookIDsRow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := idSet(bookIDs)
+	counts := make(map[int64]int64)
+	for _, ba := range r.bookAuthors {
+		if ids[ba.BookID] && !r.authors[ba.AuthorID].DeletedAt.Valid {
+			counts[ba.BookID]++
+		}
+	}
+	var items []pg.CountAuthorsByBookIDsRow
+	for bookID, count := range counts {
+		items = append(items, pg.CountAuthorsByBookIDsRow{BookID: bookID, Count: count})
+	}
+	return items, nil
+}
+
+func (r *repoSvc) ListAuthorsByIDs(ctx context.Context, ids []int64) ([]pg.Author, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var items []pg.Author
+	for _, id := range ids {
+		if author, ok := r.authors[id]; ok {
+			items = append(items, author)
+		}
+	}
+	return items, nil
+}
+
+// book queries
+
+func (r *repoSvc) CreateBook(ctx context.Context, bookArg pg.CreateBookParams, authorIDs []int64) (*pg.Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.checkBookAuthorRefs(authorIDs); err != nil {
+		return nil, err
+	}
+	r.bookSeq++
+	book := pg.Book{
+		ID:          r.bookSeq,
+		Title:       bookArg.Title,
+		Description: bookArg.Description,
+		Cover:       bookArg.Cover,
+		Version:     1,
+	}
+	r.books[book.ID] = book
+	r.setBookAuthors(book.ID, authorIDs)
+	r.record(pg.NewBookChange(ctx, pg.OpCreate, nil, &pg.BookState{Book: book, AuthorIDs: authorIDs}))
+	return &book, nil
+}
+
+func (r *repoSvc) UpdateBook(ctx context.Context, bookArg pg.UpdateBookParams, authorIDs []int64, expectedVersion *int32) (*pg.Book, []int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current, ok := r.books[bookArg.ID]
+	if !ok || current.DeletedAt.Valid {
+		return nil, nil, pg.ErrNotFound
+	}
+	if err := checkVersion(pg.EntityBook, bookArg.ID, expectedVersion, current.Version); err != nil {
+		return nil, nil, err
+	}
+	if err := r.checkBookAuthorRefs(authorIDs); err != nil {
+		return nil, nil, err
+	}
+	before := r.bookState(bookArg.ID)
+	book := pg.Book{
+		ID:          bookArg.ID,
+		Title:       bookArg.Title,
+		Description: bookArg.Description,
+		Cover:       bookArg.Cover,
+		Version:     current.Version + 1,
+	}
+	r.books[book.ID] = book
+	// the links to deleted authors are kept
+	r.removeBookAuthors(func(ba pg.BookAuthor) bool {
+		return ba.BookID == book.ID && !r.authors[ba.AuthorID].DeletedAt.Valid
+	})
+	r.setBookAuthors(book.ID, authorIDs)
+	after := &pg.BookState{Book: book, AuthorIDs: authorIDs}
+	r.record(pg.NewBookChange(ctx, pg.OpUpdate, before, after))
+	return &book, pg.AddedAuthorIDs(before, after), nil
+}
+
+func (r *repoSvc) DeleteBook(ctx context.Context, id int64) (pg.Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	book, ok := r.books[id]
+	if !ok || book.DeletedAt.Valid {
+		return pg.Book{}, pg.ErrNotFound
+	}
+	before := r.bookState(id)
+	book.DeletedAt = deletedNow()
+	book.Version++
+	r.books[id] = book
+	r.record(pg.NewBookChange(ctx, pg.OpDelete, before, nil))
+	return book, nil
+}
+
+func (r *repoSvc) RestoreBook(ctx context.Context, id int64) (pg.Book, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	book, ok := r.books[id]
+	if !ok || !book.DeletedAt.Valid {
+		return pg.Book{}, pg.ErrNotFound
+	}
+	book.DeletedAt = sql.NullTime{}
+	book.Version++
+	r.books[id] = book
+	r.record(pg.NewBookChange(ctx, pg.OpRestore, nil, r.bookState(id)))
+	return book, nil
+}
+
+func (r *repoSvc) GetBook(ctx context.Context, arg pg.GetBookParams) (pg.Book, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	book, ok := r.books[arg.ID]
+	if !ok || book.DeletedAt.Valid && !arg.IncludeDeleted {
+		return pg.Book{}, pg.ErrNotFound
+	}
+	return book, nil
+}
+
+func (r *repoSvc) ListBooks(ctx context.Context, filter pg.BookFilter, page pg.Page) ([]pg.Book, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, err := bookSortKey(page.Order.Field)
+	if err != nil {
+		return nil, err
+	}
+	ks := pageKeyset(page)
+	var items []pg.Book
+	for _, book := range r.books {
+		if r.matchBook(filter, book) && ks.match(key(book), book.ID) {
+			items = append(items, book)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return ks.less(key(items[i]), items[i].ID, key(items[j]), items[j].ID)
+	})
+	return items[:ks.limit(len(items))], nil
+}
+
+func (r *repoSvc) CountBooks(ctx context.Context, filter pg.BookFilter) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var count int64
+	for _, book := range r.books {
+		if r.matchBook(filter, book) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *repoSvc) ListBooksByAuthorIDsPage(ctx context.Context, arg pg.ListBooksByAuthorIDsPageParams) ([]pg.ListBooksByAuthorIDsPageRow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ks := keyset{
+		HasAfter:  arg.HasAfter,
+		AfterKey:  arg.AfterKey,
+		AfterID:   arg.AfterID,
+		HasBefore: arg.HasBefore,
+		BeforeKey: arg.BeforeKey,
+		BeforeID:  arg.BeforeID,
+		Reverse:   arg.Reverse,
+		RowLimit:  arg.RowLimit,
+	}
+	ids := idSet(arg.AuthorIds)
+	var items []pg.ListBooksByAuthorIDsPageRow
+	for _, ba := range r.bookAuthors {
+		book := r.books[ba.BookID]
+		if ids[ba.AuthorID] && !book.DeletedAt.Valid && ks.match(book.Title, book.ID) {
+			items = append(items, pg.ListBooksByAuthorIDsPageRow{
+				ID:          book.ID,
+				Title:       book.Title,
+				Description: book.Description,
+				Cover:       book.Cover,
+				Version:     book.Version,
+				AuthorID:    ba.AuthorID,
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].AuthorID != items[j].AuthorID {
+			return items[i].AuthorID < items[j].AuthorID
+		}
+		return ks.less(items[i].Title, items[i].ID, items[j].Title, items[j].ID)
+	})
+	var page []pg.ListBooksByAuthorIDsPageRow
+	for n, i := 0, 0; i < len(items); i++ {
+		if i > 0 && items[i].AuthorID != items[i-1].AuthorID {
+			n = 0
+		}
+		if n++; n <= int(ks.RowLimit) {
+			page = append(page, items[i])
+		}
+	}
+	return page, nil
+}
+
+func (r *repoSvc) CountBooksByAuthorIDs(ctx context.Context, authorIDs []int64) ([]pg.CountBooksByAuthorIDsRow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := idSet(authorIDs)
+	counts := make(map[int64]int64)
+	for _, ba := range r.bookAuthors {
+		if ids[ba.AuthorID] && !r.books[ba.BookID].DeletedAt.Valid {
+			counts[ba.AuthorID]++
+		}
+	}
+	var items []pg.CountBooksByAuthorIDsRow
+	for authorID, count := range counts {
+		items = append(items, pg.CountBooksByAuthorIDsRow{AuthorID: authorID, Count: count})
+	}
+	return items, nil
+}
+
+func (r *repoSvc) ListBooksByIDs(ctx context.Context, ids []int64) ([]pg.Book, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var items []pg.Book
+	for _, id := range ids {
+		if book, ok := r.books[id]; ok {
+			items = append(items, book)
+		}
+	}
+	return items, nil
+}
+
+// outbox queries
+
+// ClaimOutboxEvents returns the pending events which are due, oldest first,
+// and postpones their next attempt by the lease.
+func (r *repoSvc) ClaimOutboxEvents(ctx context.Context, arg pg.ClaimOutboxEventsParams) ([]pg.OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	var items []pg.OutboxEvent
+	for i := range r.outbox {
+		if len(items) == int(arg.BatchSize) {
+			break
+		}
+		event := &r.outbox[i]
+		if event.DeliveredAt.Valid || event.NextAttemptAt.After(now) {
+			continue
+		}
+		event.NextAttemptAt = now.Add(time.Duration(arg.LeaseMs) * time.Millisecond)
+		items = append(items, *event)
+	}
+	return items, nil
+}
+
+func (r *repoSvc) MarkOutboxEventDelivered(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.outbox {
+		if event := &r.outbox[i]; event.ID == id {
+			event.Attempts++
+			event.LastError = sql.NullString{}
+			event.DeliveredAt = sql.NullTime{Time: time.Now(), Valid: true}
+			break
+		}
+	}
+	return nil
+}
+
+func (r *repoSvc) MarkOutboxEventFailed(ctx context.Context, arg pg.MarkOutboxEventFailedParams) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.outbox {
+		if event := &r.outbox[i]; event.ID == arg.ID {
+			event.Attempts++
+			event.LastError = arg.LastError
+			event.NextAttemptAt = time.Now().Add(time.Duration(arg.BackoffMs) * time.Millisecond)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *repoSvc) PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.outbox[:0]
+	for _, event := range r.outbox {
+		if !event.DeliveredAt.Valid || !event.DeliveredAt.Time.Before(before) {
+			kept = append(kept, event)
+		}
+	}
+	n := int64(len(r.outbox) - len(kept))
+	r.outbox = kept
+	return n, nil
+}
+
+// record appends a change to the outbox and the audit log, the caller must
+// hold the write lock. Both are kept in the order of their IDs.
+func (r *repoSvc) record(change pg.Change) {
+	now := time.Now()
+	event := change.OutboxEvent()
+	r.outboxSeq++
+	r.outbox = append(r.outbox, pg.OutboxEvent{
+		ID:            r.outboxSeq,
+		Entity:        event.Entity,
+		EntityID:      event.EntityID,
+		Op:            event.Op,
+		Before:        event.Before,
+		After:         event.After,
+		Actor:         event.Actor,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	})
+	entry := change.AuditEntry()
+	r.auditSeq++
+	r.audit = append(r.audit, pg.AuditEntry{
+		ID:        r.auditSeq,
+		Entity:    entry.Entity,
+		EntityID:  entry.EntityID,
+		Op:        entry.Op,
+		Actor:     entry.Actor,
+		ChangedAt: now,
+		Changes:   entry.Changes,
+	})
+}
+
+// audit queries
+
+func (r *repoSvc) ListAuditEntries(ctx context.Context, filter pg.AuditFilter, page pg.Page) ([]pg.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// newest first, regardless of the order of page
+	page.Order = pg.AuditOrder
+	ks := pageKeyset(page)
+	var items []pg.AuditEntry
+	for _, entry := range r.audit {
+		if matchAuditEntry(filter, entry) && ks.match("", entry.ID) {
+			items = append(items, entry)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return ks.less("", items[i].ID, "", items[j].ID)
+	})
+	return items[:ks.limit(len(items))], nil
+}
+
+func (r *repoSvc) ListAuditEntriesByEntityIDsPage(ctx context.Context, arg pg.ListAuditEntriesByEntityIDsPageParams) ([]pg.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := idSet(arg.EntityIds)
+	var items []pg.AuditEntry
+	for _, entry := range r.audit {
+		if entry.Entity == arg.Entity && ids[entry.EntityID] && (!arg.HasAfter || entry.ID < arg.AfterID) {
+			items = append(items, entry)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].EntityID != items[j].EntityID {
+			return items[i].EntityID < items[j].EntityID
+		}
+		return items[i].ID > items[j].ID
+	})
+	var page []pg.AuditEntry
+	for n, i := 0, 0; i < len(items); i++ {
+		if i > 0 && items[i].EntityID != items[i-1].EntityID {
+			n = 0
+		}
+		if n++; n <= int(arg.RowLimit) {
+			page = append(page, items[i])
+		}
+	}
+	return page, nil
+}
+
+// purge queries
+
+// PurgeDeleted mirrors the purge of the postgres repository, where the
+// book_authors rows of the purged books and authors cascade.
+func (r *repoSvc) PurgeDeleted(ctx context.Context, before time.Time) (pg.PurgeResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var res pg.PurgeResult
+	for id, book := range r.books {
+		if book.DeletedAt.Valid && book.DeletedAt.Time.Before(before) {
+			r.removeBookAuthors(func(ba pg.BookAuthor) bool { return ba.BookID == id })
+			delete(r.books, id)
+			res.Books++
+		}
+	}
+	referenced := make(map[int64]bool)
+	for id, author := range r.authors {
+		if author.DeletedAt.Valid && author.DeletedAt.Time.Before(before) {
+			r.removeBookAuthors(func(ba pg.BookAuthor) bool { return ba.AuthorID == id })
+			delete(r.authors, id)
+			res.Authors++
+			continue
+		}
+		referenced[author.AgentID] = true
+	}
+	for id, agent := range r.agents {
+		if agent.DeletedAt.Valid && agent.DeletedAt.Time.Before(before) && !referenced[id] {
+			delete(r.agents, id)
+			res.Agents++
+		}
+	}
+	return res, nil
+}
+
+// bookState returns the book with the IDs of its authors which are not
+// deleted, the caller must hold the lock.
+func (r *repoSvc) bookState(id int64) *pg.BookState {
+	state := &pg.BookState{Book: r.books[id]}
+	for _, ba := range r.bookAuthors {
+		if ba.BookID == id && !r.authors[ba.AuthorID].DeletedAt.Valid {
+			state.AuthorIDs = append(state.AuthorIDs, ba.AuthorID)
+		}
+	}
+	return state
+}
+
+// constraint helpers, callers must hold the write lock
+
+func (r *repoSvc) checkAgentRef(agentID int64) error {
+	if agent, ok := r.agents[agentID]; !ok || agent.DeletedAt.Valid {
+		return fkError("authors", "authors_agent_id_fkey",
+			fmt.Sprintf("Key (agent_id)=(%d) is not present in table \"agents\".", agentID))
+	}
+	return nil
+}
+
+func (r *repoSvc) checkBookAuthorRefs(authorIDs []int64) error {
+	seen := make(map[int64]bool, len(authorIDs))
+	for _, authorID := range authorIDs {
+		if author, ok := r.authors[authorID]; !ok || author.DeletedAt.Valid {
+			return fkError("book_authors", "book_authors_author_id_fkey",
+				fmt.Sprintf("Key (author_id)=(%d) is not present in table \"authors\".", authorID))
+		}
+		if seen[authorID] {
+			return &pg.ConstraintError{
+				Err:        pg.ErrConflict,
+				Message:    "duplicate key value violates unique constraint \"book_authors_book_id_author_id_key\"",
+				Constraint: "book_authors_book_id_author_id_key",
+			}
+		}
+		seen[authorID] = true
+	}
+	return nil
+}
+
+func (r *repoSvc) setBookAuthors(bookID int64, authorIDs []int64) {
+	for _, authorID := range authorIDs {
+		r.bookAuthorSeq++
+		r.bookAuthors = append(r.bookAuthors, pg.BookAuthor{
+			ID:       r.bookAuthorSeq,
+			BookID:   bookID,
+			AuthorID: authorID,
+		})
+	}
+}
+
+func (r *repoSvc) removeBookAuthors(match func(pg.BookAuthor) bool) {
+	kept := r.bookAuthors[:0]
+	for _, ba := range r.bookAuthors {
+		if !match(ba) {
+			kept = append(kept, ba)
+		}
+	}
+	r.bookAuthors = kept
+}
+
+func fkError(table, constraint, detail string) error {
+	return &pg.ConstraintError{
+		Err:        pg.ErrInvalidReference,
+		Message:    fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint),
+		Detail:     detail,
+		Constraint: constraint,
+	}
+}
+
+// deletedNow is the deleted_at of the rows deleted now.
+func deletedNow() sql.NullTime {
+	return sql.NullTime{Time: time.Now(), Valid: true}
+}
+
+// checkVersion mirrors the version check of the postgres repository.
+func checkVersion(entity string, id int64, expected *int32, current int32) error {
+	if expected != nil && *expected != current {
+		return &pg.VersionError{Entity: entity, ID: id, Expected: *expected, Current: current}
+	}
+	return nil
+}
+
+func idSet(ids []int64) map[int64]bool {
+	set := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	set := make(map[int64]bool, len(ids))
+	var res []int64
+	for _, id := range ids {
+		if !set[id] {
+			set[id] = true
+			res = append(res, id)
+		}
+	}
+	return res
+}
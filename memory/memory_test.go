@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/memory"   // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"       // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/repotest" // update the username
+)
+
+func TestRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) pg.Repository {
+		return memory.NewRepository()
+	})
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+// PersistedQueryStore keeps persisted queries in memory. The queries
+// imported from manifests are kept apart from those registered by clients,
+// which are evicted when the store is full. It is safe for concurrent use.
+type PersistedQueryStore struct {
+	mu       sync.Mutex
+	size     int
+	order    *list.List // of *persistedQuery, most recently used first
+	queries  map[string]*list.Element
+	imported map[string]string
+}
+
+type persistedQuery struct {
+	hash  string
+	query string
+}
+
+// NewPersistedQueryStore returns a store evicting the least recently used
+// registered query once it holds size of them. A size of 0 keeps all
+// queries.
+func NewPersistedQueryStore(size int) *PersistedQueryStore {
+	return &PersistedQueryStore{
+		size:     size,
+		order:    list.New(),
+		queries:  make(map[string]*list.Element),
+		imported: make(map[string]string),
+	}
+}
+
+// Get returns the query with the given hash, registered or imported, or
+// pg.ErrNotFound.
+func (s *PersistedQueryStore) Get(ctx context.Context, hash string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if query, ok := s.imported[hash]; ok {
+		return query, nil
+	}
+	e, ok := s.queries[hash]
+	if !ok {
+		return "", pg.ErrNotFound
+	}
+	s.order.MoveToFront(e)
+	return e.Value.(*persistedQuery).query, nil
+}
+
+// GetImported returns the imported query with the given hash, or
+// pg.ErrNotFound.
+func (s *PersistedQueryStore) GetImported(ctx context.Context, hash string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query, ok := s.imported[hash]
+	if !ok {
+		return "", pg.ErrNotFound
+	}
+	return query, nil
+}
+
+// Put registers query under hash. Storing a hash again only marks it as
+// used.
+func (s *PersistedQueryStore) Put(ctx context.Context, hash, query string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.imported[hash]; ok {
+		return nil
+	}
+	if e, ok := s.queries[hash]; ok {
+		s.order.MoveToFront(e)
+		return nil
+	}
+	s.queries[hash] = s.order.PushFront(&persistedQuery{hash: hash, query: query})
+	if s.size > 0 && s.order.Len() > s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.queries, oldest.Value.(*persistedQuery).hash)
+	}
+	return nil
+}
+
+// Import stores query under hash as an imported query, which is never
+// evicted.
+func (s *PersistedQueryStore) Import(ctx context.Context, hash, query string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.queries[hash]; ok {
+		s.order.Remove(e)
+		delete(s.queries, hash)
+	}
+	s.imported[hash] = query
+	return nil
+}
@@ -0,0 +1,47 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/memory" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"     // update the username
+)
+
+func TestPersistedQueryStore(t *testing.T) {
+	ctx := context.Background()
+	s := memory.NewPersistedQueryStore(1)
+	if err := s.Import(ctx, "imported", "{ agents { id } }"); err != nil {
+		t.Fatal(err)
+	}
+	for _, hash := range []string{"first", "second"} {
+		if err := s.Put(ctx, hash, "{ "+hash+" }"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the registered queries are evicted, the imported ones are kept
+	if _, err := s.Get(ctx, "first"); !errors.Is(err, pg.ErrNotFound) {
+		t.Errorf("Get of an evicted query: got %v, want %v", err, pg.ErrNotFound)
+	}
+	if _, err := s.Get(ctx, "second"); err != nil {
+		t.Errorf("Get of a registered query: %v", err)
+	}
+	if _, err := s.Get(ctx, "imported"); err != nil {
+		t.Errorf("Get of an imported query: %v", err)
+	}
+	// only the imported queries are allowed
+	if _, err := s.GetImported(ctx, "second"); !errors.Is(err, pg.ErrNotFound) {
+		t.Errorf("GetImported of a registered query: got %v, want %v", err, pg.ErrNotFound)
+	}
+	if q, err := s.GetImported(ctx, "imported"); err != nil || q != "{ agents { id } }" {
+		t.Errorf("GetImported = %q, %v", q, err)
+	}
+	// registering an imported query leaves it imported
+	if err := s.Put(ctx, "imported", "{ agents { id } }"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetImported(ctx, "imported"); err != nil {
+		t.Errorf("GetImported after Put: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+// The weights of the parts of a document, those of the A and B labels of
+// ts_rank.
+const (
+	weightA = 1.0
+	weightB = 0.4
+)
+
+// searchPart is a part of a searchable document with its weight.
+type searchPart struct {
+	text   string
+	weight float32
+}
+
+// Search approximates the full-text search of the postgres repository: all
+// words of the query must appear in the document, regardless of case, and
+// the rank is the weighted share of the words of the document that match.
+// There is no stemming and no support for the operators of the web search
+// syntax.
+func (r *repoSvc) Search(ctx context.Context, arg pg.SearchParams) ([]pg.SearchRow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	terms := searchWords(arg.Query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	entities := make(map[string]bool, len(arg.Entities))
+	for _, entity := range arg.Entities {
+		entities[entity] = true
+	}
+	var items []pg.SearchRow
+	add := func(entity string, id int64, parts ...searchPart) {
+		if !entities[entity] {
+			return
+		}
+		row, ok := searchRow(terms, parts)
+		if !ok {
+			return
+		}
+		row.Entity, row.ID = entity, id
+		if arg.HasAfter && !searchBefore(row, arg.AfterRank, arg.AfterEntity, arg.AfterID) {
+			return
+		}
+		items = append(items, row)
+	}
+	for _, agent := range r.agents {
+		if !agent.DeletedAt.Valid {
+			add(pg.EntityAgent, agent.ID, searchPart{agent.Name, weightA})
+		}
+	}
+	for _, author := range r.authors {
+		if !author.DeletedAt.Valid {
+			add(pg.EntityAuthor, author.ID, searchPart{author.Name, weightA})
+		}
+	}
+	for _, book := range r.books {
+		if !book.DeletedAt.Valid {
+			add(pg.EntityBook, book.ID, searchPart{book.Title, weightA}, searchPart{book.Description, weightB})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return searchBefore(items[j], items[i].Rank, items[i].Entity, items[i].ID)
+	})
+	if len(items) > int(arg.RowLimit) {
+		items = items[:arg.RowLimit]
+	}
+	return items, nil
+}
+
+// searchRow ranks the document made of parts and highlights the matched
+// words of its text, it reports whether the document matches all terms.
+func searchRow(terms []string, parts []searchPart) (pg.SearchRow, bool) {
+	found := make(map[string]bool, len(terms))
+	var score float32
+	var total int
+	var snippet []string
+	for _, part := range parts {
+		for _, field := range strings.Fields(part.text) {
+			total++
+			words := searchWords(field)
+			if len(words) == 1 && containsString(terms, words[0]) {
+				found[words[0]] = true
+				score += part.weight
+				field = "<b>" + field + "</b>"
+			}
+			snippet = append(snippet, field)
+		}
+	}
+	if len(found) < len(terms) {
+		return pg.SearchRow{}, false
+	}
+	return pg.SearchRow{
+		Rank:    score / float32(total),
+		Snippet: strings.Join(snippet, " "),
+	}, true
+}
+
+// searchBefore reports whether row comes after the given position in the
+// order of the results, the (rank, entity, id) < (rank, entity, id) row
+// comparison.
+func searchBefore(row pg.SearchRow, rank float32, entity string, id int64) bool {
+	if row.Rank != rank {
+		return row.Rank < rank
+	}
+	if row.Entity != entity {
+		return row.Entity < entity
+	}
+	return row.ID < id
+}
+
+// searchWords returns the lower case words of s.
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,270 @@
+// Package metrics exposes Prometheus metrics of the GraphQL handler, the
+// dataloaders and the database.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/handler"
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"     // update the username
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gqlgen_sqlc_example"
+
+// Metrics holds the collectors of the server.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	operationDuration *prometheus.HistogramVec
+	operationErrors   *prometheus.CounterVec
+	resolverDuration  *prometheus.HistogramVec
+	resolverErrors    *prometheus.CounterVec
+	batchSize         *prometheus.HistogramVec
+	batchDuration     *prometheus.HistogramVec
+	batchErrors       *prometheus.CounterVec
+	queryDuration     *prometheus.HistogramVec
+	queryErrors       *prometheus.CounterVec
+
+	// knownOperations are the operations recorded under their own name,
+	// seenOperations are the first maxOperations other named operations,
+	// recorded under their own name as well
+	knownOperations map[string]bool
+	maxOperations   int
+	mu              sync.Mutex
+	seenOperations  map[string]bool
+}
+
+// New returns Metrics registered in a new registry, together with the Go
+// runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of GraphQL operations.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		operationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "operation_errors_total",
+			Help:      "Number of errors returned by GraphQL operations.",
+		}, []string{"operation"}),
+		resolverDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "resolver_duration_seconds",
+			Help:      "Duration of GraphQL field resolvers.",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"object", "field"}),
+		resolverErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "graphql",
+			Name:      "resolver_errors_total",
+			Help:      "Number of errors returned by GraphQL field resolvers.",
+		}, []string{"object", "field"}),
+		batchSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "dataloader",
+			Name:      "batch_size",
+			Help:      "Number of keys in the batches fetched by the dataloaders.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 8),
+		}, []string{"loader"}),
+		batchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "dataloader",
+			Name:      "batch_duration_seconds",
+			Help:      "Time the dataloaders spend fetching a batch.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"loader"}),
+		batchErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "dataloader",
+			Name:      "batch_errors_total",
+			Help:      "Number of batches the dataloaders failed to fetch.",
+		}, []string{"loader"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of database queries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"query"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_errors_total",
+			Help:      "Number of failed database queries.",
+		}, []string{"query"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.operationDuration,
+		m.operationErrors,
+		m.resolverDuration,
+		m.resolverErrors,
+		m.batchSize,
+		m.batchDuration,
+		m.batchErrors,
+		m.queryDuration,
+		m.queryErrors,
+	)
+	return m
+}
+
+// Handler returns the handler of the /metrics endpoint.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDB exposes the connection pool statistics of db.
+func (m *Metrics) RegisterDB(db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// KnownOperations adds names to the operations whose metrics are recorded
+// under their own name, e.g. those of the persisted query manifest. As
+// clients choose the names, the other named operations are recorded as
+// "other" beyond the first ones, see MaxOperations, so that they cannot grow
+// the number of series without bound. It must be called before the handler
+// serves requests.
+func (m *Metrics) KnownOperations(names []string) {
+	if m.knownOperations == nil {
+		m.knownOperations = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		m.knownOperations[name] = true
+	}
+}
+
+// MaxOperations sets the number of named operations which are not known but
+// are recorded under their own name nonetheless, the first ones seen. It
+// must be called before the handler serves requests.
+func (m *Metrics) MaxOperations(n int) {
+	m.maxOperations = n
+}
+
+// HandlerOptions returns the options instrumenting the GraphQL handler.
+func (m *Metrics) HandlerOptions() []handler.Option {
+	return []handler.Option{
+		handler.RequestMiddleware(m.observeOperation),
+		handler.ResolverMiddleware(m.observeResolver),
+	}
+}
+
+func (m *Metrics) observeOperation(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+	start := time.Now()
+	res := next(ctx)
+	rctx := graphql.GetRequestContext(ctx)
+	op := m.operationLabel(rctx)
+	m.operationDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+	if n := len(rctx.Errors); n > 0 {
+		m.operationErrors.WithLabelValues(op).Add(float64(n))
+	}
+	return res
+}
+
+// operationLabel returns the name of the operation if it is known or among
+// the first ones seen, "other" for the other named operations and
+// "anonymous" for the unnamed ones.
+func (m *Metrics) operationLabel(rctx *graphql.RequestContext) string {
+	name := gqlgen.OperationName(rctx)
+	if name == "anonymous" || m.knownOperations[name] {
+		return name
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.seenOperations[name] {
+		if len(m.seenOperations) >= m.maxOperations {
+			return "other"
+		}
+		if m.seenOperations == nil {
+			m.seenOperations = make(map[string]bool)
+		}
+		m.seenOperations[name] = true
+	}
+	return name
+}
+
+func (m *Metrics) observeResolver(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	rctx := graphql.GetResolverContext(ctx)
+	// fields without a resolver method only read a struct field
+	if !rctx.IsMethod {
+		return next(ctx)
+	}
+	start := time.Now()
+	res, err := next(ctx)
+	m.resolverDuration.WithLabelValues(rctx.Object, rctx.Field.Name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.resolverErrors.WithLabelValues(rctx.Object, rctx.Field.Name).Inc()
+	}
+	return res, err
+}
+
+// StartBatch implements dataloaders.BatchObserver.
+func (m *Metrics) StartBatch(ctx context.Context, loader string, keys int) (context.Context, func(error)) {
+	start := time.Now()
+	m.batchSize.WithLabelValues(loader).Observe(float64(keys))
+	return ctx, func(err error) {
+		m.batchDuration.WithLabelValues(loader).Observe(time.Since(start).Seconds())
+		if err != nil {
+			m.batchErrors.WithLabelValues(loader).Inc()
+		}
+	}
+}
+
+// DBTX is a pg.DBTXMiddleware recording the latency of every query by the
+// name sqlc gave it.
+func (m *Metrics) DBTX(next pg.DBTX) pg.DBTX {
+	return &dbtx{next: next, m: m}
+}
+
+func (m *Metrics) observeQuery(query string, start time.Time, err error) {
+	name := pg.QueryName(query)
+	m.queryDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.queryErrors.WithLabelValues(name).Inc()
+	}
+}
+
+type dbtx struct {
+	next pg.DBTX
+	m    *Metrics
+}
+
+func (d *dbtx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := d.next.ExecContext(ctx, query, args...)
+	d.m.observeQuery(query, start, err)
+	return res, err
+}
+
+func (d *dbtx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.next.PrepareContext(ctx, query)
+}
+
+func (d *dbtx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := d.next.QueryContext(ctx, query, args...)
+	d.m.observeQuery(query, start, err)
+	return rows, err
+}
+
+func (d *dbtx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := d.next.QueryRowContext(ctx, query, args...)
+	d.m.observeQuery(query, start, row.Err())
+	return row
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestOperationLabel(t *testing.T) {
+	m := New()
+	m.KnownOperations([]string{"ListAgents"})
+	doc := func(name string) *ast.QueryDocument {
+		return &ast.QueryDocument{Operations: ast.OperationList{{Name: name}}}
+	}
+	tests := []struct {
+		name string
+		rctx *graphql.RequestContext
+		want string
+	}{
+		{"known", &graphql.RequestContext{Doc: doc("ListAgents")}, "ListAgents"},
+		{"known by operation name", &graphql.RequestContext{OperationName: "ListAgents"}, "ListAgents"},
+		{"unknown", &graphql.RequestContext{Doc: doc("Random1234")}, "other"},
+		{"unnamed", &graphql.RequestContext{Doc: doc("")}, "anonymous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.operationLabel(tt.rctx); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Without known operations, the first named operations are recorded under
+// their own name.
+func TestOperationLabelFirstSeen(t *testing.T) {
+	m := New()
+	m.MaxOperations(2)
+	for _, tt := range []struct{ name, want string }{
+		{"ListAgents", "ListAgents"},
+		{"ListBooks", "ListBooks"},
+		{"Random1234", "other"},
+		{"ListAgents", "ListAgents"},
+		{"", "anonymous"},
+	} {
+		if got := m.operationLabel(&graphql.RequestContext{OperationName: tt.name}); got != tt.want {
+			t.Errorf("operationLabel(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
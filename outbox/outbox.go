@@ -0,0 +1,208 @@
+// Package outbox delivers the change events recorded by the repository to
+// external systems.
+//
+// Every change made through pg.Repository inserts an event into the outbox
+// in the same transaction, so that an event exists if and only if the change
+// was committed. The Dispatcher claims the pending events and sends them to
+// the sinks, retrying failed deliveries with exponential backoff until they
+// succeed. Delivery is at least once: an event may be sent again after a
+// failure, a crash or an expired lease, so consumers should deduplicate
+// events by their ID.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/logging" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"      // update the username
+)
+
+// Event is a change of an entity as delivered to the sinks.
+type Event struct {
+	ID       int64  `json:"id"`
+	Entity   string `json:"entity"`
+	EntityID int64  `json:"entityID"`
+	Op       string `json:"op"`
+	// Before is null for created entities, After for deleted ones.
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+	// Actor is the subject of the principal which made the change, null for
+	// anonymous changes.
+	Actor     *string   `json:"actor"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func newEvent(e pg.OutboxEvent) Event {
+	event := Event{
+		ID:        e.ID,
+		Entity:    e.Entity,
+		EntityID:  e.EntityID,
+		Op:        e.Op,
+		Before:    e.Before,
+		After:     e.After,
+		Timestamp: e.CreatedAt.UTC(),
+	}
+	if e.Actor.Valid {
+		event.Actor = &e.Actor.String
+	}
+	return event
+}
+
+// Sink receives the events. Send must return an error unless the event has
+// been durably accepted, so that it is retried.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// Store holds the outbox, it is implemented by pg.Repository.
+type Store interface {
+	ClaimOutboxEvents(ctx context.Context, arg pg.ClaimOutboxEventsParams) ([]pg.OutboxEvent, error)
+	MarkOutboxEventDelivered(ctx context.Context, id int64) error
+	MarkOutboxEventFailed(ctx context.Context, arg pg.MarkOutboxEventFailedParams) error
+}
+
+// Dispatcher defaults
+const (
+	DefaultPollInterval = time.Second
+	DefaultBatchSize    = 100
+	DefaultLease        = 5 * time.Minute
+	DefaultMaxBackoff   = 10 * time.Minute
+)
+
+// minBackoff is the delay before the first retry of a failed event.
+const minBackoff = time.Second
+
+// Dispatcher delivers the events of the outbox to the sinks. Several
+// dispatchers, e.g. of different servers, may share a store: the events
+// claimed by one are leased to it and skipped by the others.
+type Dispatcher struct {
+	Store  Store
+	Sinks  []Sink
+	Logger *logging.Logger
+
+	// PollInterval is how often the outbox is checked for due events.
+	PollInterval time.Duration
+	// BatchSize is the maximum number of events claimed at once.
+	BatchSize int
+	// Lease is how long claimed events are reserved, it should exceed the
+	// time needed to deliver a batch, or events are delivered twice.
+	Lease time.Duration
+	// MaxBackoff caps the delay between the retries of an event.
+	MaxBackoff time.Duration
+}
+
+// Run delivers the events until ctx is done. Events being delivered when ctx
+// is done are not marked, they are delivered again once their lease expires.
+func (d *Dispatcher) Run(ctx context.Context) {
+	pollInterval := d.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		n, err := d.dispatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.Logger.Warn("outbox dispatch failed", "error", err.Error())
+		}
+		// keep going while there is a backlog
+		if err == nil && n == d.batchSize() {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch delivers a batch of due events and returns their number.
+func (d *Dispatcher) dispatch(ctx context.Context) (int, error) {
+	lease := d.Lease
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+	events, err := d.Store.ClaimOutboxEvents(ctx, pg.ClaimOutboxEventsParams{
+		LeaseMs:   lease.Milliseconds(),
+		BatchSize: int32(d.batchSize()),
+	})
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	for _, e := range events {
+		if ctx.Err() != nil {
+			return len(events), nil
+		}
+		if err := d.deliver(ctx, newEvent(e)); err != nil {
+			if ctx.Err() != nil {
+				return len(events), nil
+			}
+			backoff := d.backoff(int(e.Attempts) + 1)
+			d.Logger.Warn("outbox event delivery failed",
+				"event", e.ID, "entity", e.Entity, "op", e.Op,
+				"attempts", e.Attempts+1, "retry_in", backoff.String(), "error", err.Error())
+			if err := d.Store.MarkOutboxEventFailed(ctx, pg.MarkOutboxEventFailedParams{
+				ID:        e.ID,
+				LastError: sql.NullString{String: err.Error(), Valid: true},
+				BackoffMs: backoff.Milliseconds(),
+			}); err != nil {
+				return len(events), err
+			}
+			continue
+		}
+		if err := d.Store.MarkOutboxEventDelivered(ctx, e.ID); err != nil {
+			return len(events), err
+		}
+	}
+	return len(events), nil
+}
+
+// deliver sends event to every sink. An event which fails for some sinks is
+// sent to all of them again.
+func (d *Dispatcher) deliver(ctx context.Context, event Event) error {
+	var errs []string
+	for _, sink := range d.Sinks {
+		if err := sink.Send(ctx, event); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (d *Dispatcher) batchSize() int {
+	if d.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return d.BatchSize
+}
+
+// backoff returns the delay before the next attempt after the given number
+// of failed attempts, doubling from minBackoff up to MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	maxBackoff := d.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+	backoff := minBackoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,182 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/logging" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"      // update the username
+)
+
+// fakeStore returns its events from the first claim and records how they
+// are marked.
+type fakeStore struct {
+	events    []pg.OutboxEvent
+	claimed   []pg.ClaimOutboxEventsParams
+	delivered []int64
+	failed    []pg.MarkOutboxEventFailedParams
+}
+
+func (s *fakeStore) ClaimOutboxEvents(ctx context.Context, arg pg.ClaimOutboxEventsParams) ([]pg.OutboxEvent, error) {
+	s.claimed = append(s.claimed, arg)
+	events := s.events
+	s.events = nil
+	return events, nil
+}
+
+func (s *fakeStore) MarkOutboxEventDelivered(ctx context.Context, id int64) error {
+	s.delivered = append(s.delivered, id)
+	return nil
+}
+
+func (s *fakeStore) MarkOutboxEventFailed(ctx context.Context, arg pg.MarkOutboxEventFailedParams) error {
+	s.failed = append(s.failed, arg)
+	return nil
+}
+
+// recordingSink records the IDs of the events it receives, failing those
+// for which fail returns an error.
+type recordingSink struct {
+	mu   sync.Mutex
+	ids  []int64
+	fail func(Event) error
+}
+
+func (s *recordingSink) Send(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids = append(s.ids, event.ID)
+	if s.fail != nil {
+		return s.fail(event)
+	}
+	return nil
+}
+
+func events(attempts int32, ids ...int64) []pg.OutboxEvent {
+	var events []pg.OutboxEvent
+	for _, id := range ids {
+		events = append(events, pg.OutboxEvent{ID: id, Entity: pg.EntityBook, EntityID: id, Op: "update", Attempts: attempts})
+	}
+	return events
+}
+
+func newDispatcher(store Store, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{
+		Store:  store,
+		Sinks:  sinks,
+		Logger: logging.New(ioutil.Discard, logging.LevelError),
+	}
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDispatchOrder(t *testing.T) {
+	store := &fakeStore{events: events(0, 3, 1, 2)}
+	sink := &recordingSink{}
+	d := newDispatcher(store, sink)
+	n, err := d.dispatch(context.Background())
+	if err != nil || n != 3 {
+		t.Fatalf("dispatch = %d, %v, want 3 events", n, err)
+	}
+	if len(store.claimed) != 1 || store.claimed[0].LeaseMs != DefaultLease.Milliseconds() || store.claimed[0].BatchSize != DefaultBatchSize {
+		t.Errorf("claimed %+v, want the default lease and batch size", store.claimed)
+	}
+	// the events are delivered in the order they were recorded
+	if want := []int64{1, 2, 3}; !equalIDs(sink.ids, want) {
+		t.Errorf("sent %v, want %v", sink.ids, want)
+	}
+	if want := []int64{1, 2, 3}; !equalIDs(store.delivered, want) {
+		t.Errorf("delivered %v, want %v", store.delivered, want)
+	}
+	if len(store.failed) != 0 {
+		t.Errorf("failed %+v, want none", store.failed)
+	}
+}
+
+func TestDispatchFailure(t *testing.T) {
+	store := &fakeStore{events: events(2, 1, 2, 3)}
+	ok := &recordingSink{}
+	failing := &recordingSink{fail: func(e Event) error {
+		if e.ID == 2 {
+			return errors.New("unavailable")
+		}
+		return nil
+	}}
+	d := newDispatcher(store, failing, ok)
+	if _, err := d.dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// an event failing for one sink is still sent to the others, and to all
+	// of them again on the next attempt
+	if want := []int64{1, 2, 3}; !equalIDs(ok.ids, want) {
+		t.Errorf("sent %v to the other sink, want %v", ok.ids, want)
+	}
+	if want := []int64{1, 3}; !equalIDs(store.delivered, want) {
+		t.Errorf("delivered %v, want %v", store.delivered, want)
+	}
+	if len(store.failed) != 1 {
+		t.Fatalf("failed %+v, want event 2", store.failed)
+	}
+	f := store.failed[0]
+	// the event failed for the third time
+	if f.ID != 2 || f.BackoffMs != (4*time.Second).Milliseconds() || !f.LastError.Valid || !strings.Contains(f.LastError.String, "unavailable") {
+		t.Errorf("failed %+v, want event 2 retried in 4s after unavailable", f)
+	}
+}
+
+func TestDispatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	store := &fakeStore{events: events(0, 1, 2)}
+	sink := &recordingSink{fail: func(Event) error {
+		cancel()
+		return ctx.Err()
+	}}
+	d := newDispatcher(store, sink)
+	if _, err := d.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// the event being delivered is left to the expiry of its lease
+	if len(sink.ids) != 1 || len(store.delivered) != 0 || len(store.failed) != 0 {
+		t.Errorf("sent %v, delivered %v, failed %+v, want event 1 sent only", sink.ids, store.delivered, store.failed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		maxBackoff time.Duration
+		attempts   int
+		want       time.Duration
+	}{
+		{0, 1, time.Second},
+		{0, 2, 2 * time.Second},
+		{0, 3, 4 * time.Second},
+		{0, 10, 512 * time.Second},
+		{0, 11, DefaultMaxBackoff},
+		{0, 1000, DefaultMaxBackoff},
+		{5 * time.Second, 3, 4 * time.Second},
+		{5 * time.Second, 4, 5 * time.Second},
+		{5 * time.Second, 1000, 5 * time.Second},
+		{500 * time.Millisecond, 1, 500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		d := &Dispatcher{MaxBackoff: tt.maxBackoff}
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("MaxBackoff %v: backoff(%d) = %v, want %v", tt.maxBackoff, tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// WebhookSink POSTs every event as JSON to a URL. Responses other than 2xx
+// fail the delivery. The X-Outbox-Event-ID header carries the event ID, for
+// the receiver to deduplicate redelivered events.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// Send posts event to the webhook.
+func (s *WebhookSink) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Outbox-Event-ID", strconv.FormatInt(event.ID, 10))
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	// let the connection be reused
+	io.Copy(ioutil.Discard, io.LimitReader(res.Body, 64<<10))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", res.Status)
+	}
+	return nil
+}
+
+// WriterSink writes every event as a line of JSON (NDJSON) to a writer, e.g.
+// os.Stdout. It is safe for concurrent use.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink returns a sink writing to w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Send writes event to the writer.
+func (s *WriterSink) Send(ctx context.Context, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// FileSink appends every event as a line of JSON (NDJSON) to a file, which
+// is synced before the event counts as delivered.
+type FileSink struct {
+	WriterSink
+	f *os.File
+}
+
+// OpenFileSink opens, or creates, the file appended to by the sink.
+func OpenFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{WriterSink: WriterSink{w: f}, f: f}, nil
+}
+
+// Send appends event to the file.
+func (s *FileSink) Send(ctx context.Context, event Event) error {
+	if err := s.WriterSink.Send(ctx, event); err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	return s.f.Close()
+}
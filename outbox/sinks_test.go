@@ -0,0 +1,124 @@
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEvent(id int64) Event {
+	actor := "editor"
+	return Event{
+		ID:        id,
+		Entity:    "book",
+		EntityID:  7,
+		Op:        "update",
+		Before:    json.RawMessage(`{"title":"Before"}`),
+		After:     json.RawMessage(`{"title":"After"}`),
+		Actor:     &actor,
+		Timestamp: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got struct {
+		header http.Header
+		event  Event
+	}
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.header = r.Header
+		if err := json.NewDecoder(r.Body).Decode(&got.event); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	sink := &WebhookSink{URL: srv.URL}
+
+	if err := sink.Send(context.Background(), testEvent(42)); err != nil {
+		t.Fatal(err)
+	}
+	if ct := got.header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if id := got.header.Get("X-Outbox-Event-ID"); id != "42" {
+		t.Errorf("X-Outbox-Event-ID = %q, want 42", id)
+	}
+	if got.event.ID != 42 || got.event.EntityID != 7 || string(got.event.After) != `{"title":"After"}` || *got.event.Actor != "editor" {
+		t.Errorf("event = %+v", got.event)
+	}
+
+	for _, status = range []int{http.StatusMultipleChoices, http.StatusBadRequest, http.StatusInternalServerError} {
+		err := sink.Send(context.Background(), testEvent(43))
+		if err == nil || !strings.Contains(err.Error(), http.StatusText(status)) {
+			t.Errorf("status %d: got %v, want an error", status, err)
+		}
+	}
+
+	srv.Close()
+	if err := sink.Send(context.Background(), testEvent(44)); err == nil {
+		t.Error("closed server: got no error")
+	}
+}
+
+// readLines returns the events of the NDJSON lines of b.
+func readLines(t *testing.T, b []byte) []Event {
+	t.Helper()
+	var events []Event
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		var e Event
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatalf("line %q: %v", s.Text(), err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+	for _, id := range []int64{1, 2} {
+		if err := sink.Send(context.Background(), testEvent(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	events := readLines(t, buf.Bytes())
+	if len(events) != 2 || events[0].ID != 1 || events[1].ID != 2 || !events[1].Timestamp.Equal(testEvent(2).Timestamp) {
+		t.Errorf("events = %+v", events)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	// the file is appended to across restarts
+	for _, id := range []int64{1, 2} {
+		sink, err := OpenFileSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Send(context.Background(), testEvent(id)); err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events := readLines(t, b); len(events) != 2 || events[0].ID != 1 || events[1].ID != 2 {
+		t.Errorf("events = %+v", events)
+	}
+}
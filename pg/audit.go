@@ -0,0 +1,143 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditOrder is the order of the audit entries: newest first.
+var AuditOrder = Order{Field: SortByID, Desc: true}
+
+var auditSortColumns = map[SortField]string{
+	SortByID: "id",
+}
+
+// AuditFilter restricts the entries returned by ListAuditEntries.
+type AuditFilter struct {
+	Entity   *string
+	EntityID *int64
+	Op       *string
+	Actor    *string
+	// Since and Until bound the time of the change, Until is exclusive.
+	Since *time.Time
+	Until *time.Time
+}
+
+func (f AuditFilter) apply(b *selectBuilder) {
+	if f.Entity != nil {
+		b.where("entity = ?", *f.Entity)
+	}
+	if f.EntityID != nil {
+		b.where("entity_id = ?", *f.EntityID)
+	}
+	if f.Op != nil {
+		b.where("op = ?", *f.Op)
+	}
+	if f.Actor != nil {
+		b.where("actor = ?", *f.Actor)
+	}
+	if f.Since != nil {
+		b.where("changed_at >= ?", *f.Since)
+	}
+	if f.Until != nil {
+		b.where("changed_at < ?", *f.Until)
+	}
+}
+
+// ListAuditEntries returns a page of the audit entries matching filter,
+// newest first. The order of page is ignored.
+func (q *Queries) ListAuditEntries(ctx context.Context, filter AuditFilter, page Page) ([]AuditEntry, error) {
+	b := newSelect("ListAuditEntries", "id, entity, entity_id, op, actor, changed_at, changes", "audit_entries")
+	filter.apply(b)
+	page.Order = AuditOrder
+	if err := b.paginate(page, auditSortColumns); err != nil {
+		return nil, err
+	}
+	query, args, err := b.query()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEntry
+	for rows.Next() {
+		var i AuditEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.Entity,
+			&i.EntityID,
+			&i.Op,
+			&i.Actor,
+			&i.ChangedAt,
+			&i.Changes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// AuditEntriesByEntityIDsParams returns the arguments of the
+// ListAuditEntriesByEntityIDsPage query. Only forward pages are supported.
+func (p Page) AuditEntriesByEntityIDsParams(entity string, entityIDs []int64) ListAuditEntriesByEntityIDsPageParams {
+	return ListAuditEntriesByEntityIDsPageParams{
+		Entity:    entity,
+		EntityIds: entityIDs,
+		HasAfter:  p.HasAfter,
+		AfterID:   p.After.ID,
+		RowLimit:  p.rowLimit(),
+	}
+}
+
+// FieldChanges decodes the changes of the entry.
+func (e AuditEntry) FieldChanges() ([]FieldChange, error) {
+	var changes []FieldChange
+	err := json.Unmarshal(e.Changes, &changes)
+	return changes, err
+}
+
+// AuditEntryEdge is an audit entry together with its cursor.
+type AuditEntryEdge struct {
+	Cursor string
+	Node   AuditEntry
+}
+
+// AuditEntryConnection is a page of audit entries. It has no total count,
+// which would have to count the whole audit log.
+type AuditEntryConnection struct {
+	Edges    []AuditEntryEdge
+	PageInfo PageInfo
+}
+
+// NewAuditEntryConnection builds a connection from the rows returned by a
+// page query executed with the arguments of page.
+func NewAuditEntryConnection(page Page, items []AuditEntry) *AuditEntryConnection {
+	n, pageInfo := page.window(len(items))
+	conn := &AuditEntryConnection{
+		Edges:    make([]AuditEntryEdge, n),
+		PageInfo: pageInfo,
+	}
+	for i := range conn.Edges {
+		item := items[page.index(i, n)]
+		conn.Edges[i] = AuditEntryEdge{
+			Cursor: Cursor{Order: AuditOrder, ID: item.ID}.String(),
+			Node:   item,
+		}
+	}
+	if n > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[n-1].Cursor
+	}
+	return conn
+}
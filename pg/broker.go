@@ -0,0 +1,138 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// maxNotifyPayload is the largest payload postgres accepts for NOTIFY.
+const maxNotifyPayload = 7999
+
+// brokerBuffer is the number of events a slow subscriber may fall behind
+// before further events are dropped for it.
+const brokerBuffer = 64
+
+const notify = `-- name: Notify :exec
+SELECT pg_notify($1, $2)
+`
+
+// Broker delivers events through postgres LISTEN/NOTIFY, so that the
+// subscribers of every server sharing the database receive them. Topics are
+// used as channel names. Events published while the listening connection is
+// being reestablished are lost.
+type Broker struct {
+	db       DBTX
+	listener *pq.Listener
+
+	// listenMu serializes LISTEN and UNLISTEN, which wait for the server
+	listenMu  sync.Mutex
+	listening map[string]int
+
+	mu   sync.Mutex
+	subs map[string]map[chan []byte]struct{}
+}
+
+// NewBroker returns a Broker publishing with db, wrapped by the middlewares,
+// and listening on a dedicated connection to dataSourceName.
+func NewBroker(db *sql.DB, dataSourceName string, middlewares ...DBTXMiddleware) *Broker {
+	b := &Broker{
+		db:        wrapDBTX(db, middlewares),
+		listener:  pq.NewListener(dataSourceName, 100*time.Millisecond, time.Minute, nil),
+		listening: make(map[string]int),
+		subs:      make(map[string]map[chan []byte]struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Publish notifies the subscribers of topic on every server.
+func (b *Broker) Publish(ctx context.Context, topic string, payload []byte) error {
+	if len(payload) > maxNotifyPayload {
+		return fmt.Errorf("pg: %d byte payload exceeds the NOTIFY limit", len(payload))
+	}
+	_, err := b.db.ExecContext(ctx, notify, topic, string(payload))
+	return err
+}
+
+// Subscribe returns a channel of the payloads published on topic, which is
+// closed when ctx is done.
+func (b *Broker) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	b.listenMu.Lock()
+	defer b.listenMu.Unlock()
+	if b.listening[topic] == 0 {
+		if err := b.listener.Listen(topic); err != nil && err != pq.ErrChannelAlreadyOpen {
+			return nil, err
+		}
+	}
+	b.listening[topic]++
+	ch := make(chan []byte, brokerBuffer)
+	b.mu.Lock()
+	if b.subs[topic] == nil {
+		b.subs[topic] = make(map[chan []byte]struct{})
+	}
+	b.subs[topic][ch] = struct{}{}
+	b.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(topic, ch)
+	}()
+	return ch, nil
+}
+
+func (b *Broker) unsubscribe(topic string, ch chan []byte) {
+	b.mu.Lock()
+	delete(b.subs[topic], ch)
+	if len(b.subs[topic]) == 0 {
+		delete(b.subs, topic)
+	}
+	close(ch)
+	b.mu.Unlock()
+
+	b.listenMu.Lock()
+	defer b.listenMu.Unlock()
+	b.listening[topic]--
+	if b.listening[topic] == 0 {
+		delete(b.listening, topic)
+		b.listener.Unlisten(topic)
+	}
+}
+
+// run delivers the notifications until the broker is closed.
+func (b *Broker) run() {
+	for {
+		select {
+		case n, ok := <-b.listener.Notify:
+			if !ok {
+				return
+			}
+			// nil signals a reconnection
+			if n != nil {
+				b.dispatch(n.Channel, []byte(n.Extra))
+			}
+		case <-time.After(90 * time.Second):
+			// detect a broken connection while no notifications arrive
+			go b.listener.Ping()
+		}
+	}
+}
+
+func (b *Broker) dispatch(topic string, payload []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
+
+// Close closes the listening connection.
+func (b *Broker) Close() error {
+	return b.listener.Close()
+}
@@ -0,0 +1,138 @@
+package pg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// selectBuilder composes the SELECT statements of the filtered list queries.
+//
+// Values are only ever passed as bind parameters and identifiers are only
+// taken from fixed whitelists (see the sortColumns maps), so no string
+// provided by an API client ends up in the SQL text.
+type selectBuilder struct {
+	name    string
+	columns string
+	table   string
+	conds   []string
+	args    []interface{}
+	orderBy string
+	limit   string
+	// err is the first error met while composing the statement.
+	err error
+}
+
+// newSelect starts a statement selecting columns from table. The name is
+// emitted as a comment in the same format sqlc uses for generated queries.
+func newSelect(name, columns, table string) *selectBuilder {
+	return &selectBuilder{name: name, columns: columns, table: table}
+}
+
+// bind adds a bind parameter and returns its placeholder.
+func (b *selectBuilder) bind(v interface{}) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// where adds a condition to the statement. Each ? in cond is replaced by the
+// placeholder of the corresponding argument. A condition whose number of ?
+// differs from the number of arguments makes the statement fail to build.
+func (b *selectBuilder) where(cond string, args ...interface{}) {
+	if n := strings.Count(cond, "?"); n != len(args) {
+		if b.err == nil {
+			b.err = fmt.Errorf("pg: %s: condition %q has %d placeholders for %d arguments", b.name, cond, n, len(args))
+		}
+		return
+	}
+	var sb strings.Builder
+	for _, arg := range args {
+		i := strings.IndexByte(cond, '?')
+		sb.WriteString(cond[:i])
+		sb.WriteString(b.bind(arg))
+		cond = cond[i+1:]
+	}
+	sb.WriteString(cond)
+	b.conds = append(b.conds, sb.String())
+}
+
+// paginate applies the sort order and keyset pagination of page. The sort
+// column is looked up in sortColumns and an error is returned for unknown
+// fields.
+func (b *selectBuilder) paginate(page Page, sortColumns map[SortField]string) error {
+	col, ok := sortColumns[page.Order.Field]
+	if !ok {
+		return fmt.Errorf("pg: cannot sort %s by %q", b.table, page.Order.Field)
+	}
+	after, before := ">", "<"
+	if page.Order.Desc {
+		after, before = before, after
+	}
+	if page.HasAfter {
+		b.keyset(col, after, page.After)
+	}
+	if page.HasBefore {
+		b.keyset(col, before, page.Before)
+	}
+	dir := "ASC"
+	if page.Order.Desc != page.Reverse {
+		dir = "DESC"
+	}
+	if col == "id" {
+		b.orderBy = "id " + dir
+	} else {
+		b.orderBy = col + " " + dir + ", id " + dir
+	}
+	b.limit = b.bind(page.Limit + 1)
+	return nil
+}
+
+func (b *selectBuilder) keyset(col, op string, c Cursor) {
+	if col == "id" {
+		b.where("id "+op+" ?", c.ID)
+		return
+	}
+	b.where("("+col+", id) "+op+" (?::text, ?::bigint)", c.Key, c.ID)
+}
+
+// query returns the SQL text and arguments of the statement, or the error
+// met while composing it.
+func (b *selectBuilder) query() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	return b.String(), b.args, nil
+}
+
+// String returns the SQL text of the statement.
+func (b *selectBuilder) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "-- name: %s :many\nSELECT %s FROM %s", b.name, b.columns, b.table)
+	b.writeWhere(&sb)
+	if b.orderBy != "" {
+		sb.WriteString("\nORDER BY " + b.orderBy)
+	}
+	if b.limit != "" {
+		sb.WriteString("\nLIMIT " + b.limit)
+	}
+	return sb.String()
+}
+
+// count returns the SQL text and arguments of a statement counting the rows
+// matching the conditions, or the error met while composing it. It must not
+// be combined with paginate.
+func (b *selectBuilder) count() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "-- name: %s :one\nSELECT COUNT(*) FROM %s", b.name, b.table)
+	b.writeWhere(&sb)
+	return sb.String(), b.args, nil
+}
+
+func (b *selectBuilder) writeWhere(sb *strings.Builder) {
+	if len(b.conds) > 0 {
+		sb.WriteString("\nWHERE " + strings.Join(b.conds, "\nAND "))
+	}
+}
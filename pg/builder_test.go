@@ -0,0 +1,270 @@
+package pg
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestFilters(t *testing.T) {
+	s := func(v string) *string { return &v }
+	b := func(v bool) *bool { return &v }
+	id := func(v int64) *int64 { return &v }
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	tests := []struct {
+		name      string
+		table     string
+		filter    interface{ apply(*selectBuilder) }
+		wantWhere []string
+		wantArgs  []interface{}
+	}{
+		{"agents", "agents", AgentFilter{}, []string{"deleted_at IS NULL"}, nil},
+		{"agents including deleted", "agents", AgentFilter{IncludeDeleted: true}, nil, nil},
+		{
+			"agent name", "agents", AgentFilter{NameContains: s("ann")},
+			[]string{"deleted_at IS NULL", "strpos(lower(name), lower($1::text)) > 0"},
+			[]interface{}{"ann"},
+		},
+		{
+			"agent email domain", "agents", AgentFilter{EmailDomain: s("example.com"), IncludeDeleted: true},
+			[]string{"lower(split_part(email, '@', 2)) = lower($1::text)"},
+			[]interface{}{"example.com"},
+		},
+		{
+			"agent combined", "agents", AgentFilter{NameContains: s("ann"), EmailDomain: s("example.com")},
+			[]string{"deleted_at IS NULL", "strpos(lower(name), lower($1::text)) > 0", "lower(split_part(email, '@', 2)) = lower($2::text)"},
+			[]interface{}{"ann", "example.com"},
+		},
+		{"authors", "authors", AuthorFilter{}, []string{"deleted_at IS NULL"}, nil},
+		{
+			"author name", "authors", AuthorFilter{NameContains: s("bob"), IncludeDeleted: true},
+			[]string{"strpos(lower(name), lower($1::text)) > 0"},
+			[]interface{}{"bob"},
+		},
+		{
+			"author with website", "authors", AuthorFilter{HasWebsite: b(true), IncludeDeleted: true},
+			[]string{"(website IS NOT NULL) = $1"},
+			[]interface{}{true},
+		},
+		{
+			"author without website", "authors", AuthorFilter{HasWebsite: b(false), IncludeDeleted: true},
+			[]string{"(website IS NOT NULL) = $1"},
+			[]interface{}{false},
+		},
+		{
+			"author agents", "authors", AuthorFilter{AgentIDs: []int64{1, 2}, IncludeDeleted: true},
+			[]string{"agent_id = ANY($1::bigint[])"},
+			[]interface{}{pq.Array([]int64{1, 2})},
+		},
+		{
+			// an empty list matches no author
+			"author no agents", "authors", AuthorFilter{AgentIDs: []int64{}, IncludeDeleted: true},
+			[]string{"agent_id = ANY($1::bigint[])"},
+			[]interface{}{pq.Array([]int64{})},
+		},
+		{
+			"author combined", "authors", AuthorFilter{NameContains: s("bob"), HasWebsite: b(true), AgentIDs: []int64{3}},
+			[]string{"deleted_at IS NULL", "strpos(lower(name), lower($1::text)) > 0", "(website IS NOT NULL) = $2", "agent_id = ANY($3::bigint[])"},
+			[]interface{}{"bob", true, pq.Array([]int64{3})},
+		},
+		{"books", "books", BookFilter{}, []string{"deleted_at IS NULL"}, nil},
+		{
+			"book title", "books", BookFilter{TitleContains: s("go"), IncludeDeleted: true},
+			[]string{"strpos(lower(title), lower($1::text)) > 0"},
+			[]interface{}{"go"},
+		},
+		{
+			"book description", "books", BookFilter{DescriptionContains: s("sql"), IncludeDeleted: true},
+			[]string{"strpos(lower(description), lower($1::text)) > 0"},
+			[]interface{}{"sql"},
+		},
+		{
+			"book authors", "books", BookFilter{AuthorIDs: []int64{4}, IncludeDeleted: true},
+			[]string{"id IN (SELECT book_id FROM book_authors WHERE author_id = ANY($1::bigint[]))"},
+			[]interface{}{pq.Array([]int64{4})},
+		},
+		{
+			"book combined", "books", BookFilter{TitleContains: s("go"), DescriptionContains: s("sql"), AuthorIDs: []int64{4, 5}},
+			[]string{
+				"deleted_at IS NULL",
+				"strpos(lower(title), lower($1::text)) > 0",
+				"strpos(lower(description), lower($2::text)) > 0",
+				"id IN (SELECT book_id FROM book_authors WHERE author_id = ANY($3::bigint[]))",
+			},
+			[]interface{}{"go", "sql", pq.Array([]int64{4, 5})},
+		},
+		{"audit entries", "audit_entries", AuditFilter{}, nil, nil},
+		{"audit entity", "audit_entries", AuditFilter{Entity: s("book")}, []string{"entity = $1"}, []interface{}{"book"}},
+		{"audit entity id", "audit_entries", AuditFilter{EntityID: id(7)}, []string{"entity_id = $1"}, []interface{}{int64(7)}},
+		{"audit op", "audit_entries", AuditFilter{Op: s("delete")}, []string{"op = $1"}, []interface{}{"delete"}},
+		{"audit actor", "audit_entries", AuditFilter{Actor: s("admin")}, []string{"actor = $1"}, []interface{}{"admin"}},
+		{"audit since", "audit_entries", AuditFilter{Since: &since}, []string{"changed_at >= $1"}, []interface{}{since}},
+		{"audit until", "audit_entries", AuditFilter{Until: &until}, []string{"changed_at < $1"}, []interface{}{until}},
+		{
+			"audit combined", "audit_entries", AuditFilter{Entity: s("book"), EntityID: id(7), Op: s("update"), Actor: s("admin"), Since: &since, Until: &until},
+			[]string{"entity = $1", "entity_id = $2", "op = $3", "actor = $4", "changed_at >= $5", "changed_at < $6"},
+			[]interface{}{"book", int64(7), "update", "admin", since, until},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newSelect("Count", "", tt.table)
+			tt.filter.apply(b)
+			query, args, err := b.count()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "-- name: Count :one\nSELECT COUNT(*) FROM " + tt.table
+			if len(tt.wantWhere) > 0 {
+				want += "\nWHERE " + strings.Join(tt.wantWhere, "\nAND ")
+			}
+			if query != want {
+				t.Errorf("query =\n%s\nwant\n%s", query, want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	after := Cursor{Key: "k", ID: 5}
+	before := Cursor{Key: "m", ID: 9}
+	tests := []struct {
+		name        string
+		sortColumns map[SortField]string
+		page        Page
+		wantWhere   []string
+		wantOrderBy string
+		wantArgs    []interface{}
+	}{
+		{"agents by default", agentSortColumns, Page{Limit: 10}, nil, "name ASC, id ASC", []interface{}{11}},
+		{"agents by id", agentSortColumns, Page{Order: Order{Field: SortByID}, Limit: 10}, nil, "id ASC", []interface{}{11}},
+		{"agents by name", agentSortColumns, Page{Order: Order{Field: SortByName}, Limit: 10}, nil, "name ASC, id ASC", []interface{}{11}},
+		{"agents by email", agentSortColumns, Page{Order: Order{Field: SortByEmail}, Limit: 10}, nil, "email ASC, id ASC", []interface{}{11}},
+		{"authors by default", authorSortColumns, Page{Limit: 10}, nil, "name ASC, id ASC", []interface{}{11}},
+		{"authors by id", authorSortColumns, Page{Order: Order{Field: SortByID}, Limit: 10}, nil, "id ASC", []interface{}{11}},
+		{"authors by name", authorSortColumns, Page{Order: Order{Field: SortByName}, Limit: 10}, nil, "name ASC, id ASC", []interface{}{11}},
+		{"books by default", bookSortColumns, Page{Limit: 10}, nil, "title ASC, id ASC", []interface{}{11}},
+		{"books by id", bookSortColumns, Page{Order: Order{Field: SortByID}, Limit: 10}, nil, "id ASC", []interface{}{11}},
+		{"books by title", bookSortColumns, Page{Order: Order{Field: SortByTitle}, Limit: 10}, nil, "title ASC, id ASC", []interface{}{11}},
+		{"descending", bookSortColumns, Page{Order: Order{Desc: true}, Limit: 10}, nil, "title DESC, id DESC", []interface{}{11}},
+		// the rows of reverse pages are fetched in the opposite order
+		{"reverse", bookSortColumns, Page{Limit: 10, Reverse: true}, nil, "title DESC, id DESC", []interface{}{11}},
+		{"reverse descending", bookSortColumns, Page{Order: Order{Desc: true}, Limit: 10, Reverse: true}, nil, "title ASC, id ASC", []interface{}{11}},
+		{
+			"after", bookSortColumns, Page{Limit: 10, HasAfter: true, After: after},
+			[]string{"(title, id) > ($1::text, $2::bigint)"}, "title ASC, id ASC", []interface{}{"k", int64(5), 11},
+		},
+		{
+			"after descending", bookSortColumns, Page{Order: Order{Desc: true}, Limit: 10, HasAfter: true, After: after},
+			[]string{"(title, id) < ($1::text, $2::bigint)"}, "title DESC, id DESC", []interface{}{"k", int64(5), 11},
+		},
+		{
+			"before", bookSortColumns, Page{Limit: 10, Reverse: true, HasBefore: true, Before: before},
+			[]string{"(title, id) < ($1::text, $2::bigint)"}, "title DESC, id DESC", []interface{}{"m", int64(9), 11},
+		},
+		{
+			"after and before", bookSortColumns, Page{Limit: 10, HasAfter: true, After: after, HasBefore: true, Before: before},
+			[]string{"(title, id) > ($1::text, $2::bigint)", "(title, id) < ($3::text, $4::bigint)"}, "title ASC, id ASC", []interface{}{"k", int64(5), "m", int64(9), 11},
+		},
+		{
+			"after by id", bookSortColumns, Page{Order: Order{Field: SortByID}, Limit: 10, HasAfter: true, After: Cursor{ID: 5}},
+			[]string{"id > $1"}, "id ASC", []interface{}{int64(5), 11},
+		},
+		{
+			"before by id descending", bookSortColumns, Page{Order: Order{Field: SortByID, Desc: true}, Limit: 10, Reverse: true, HasBefore: true, Before: Cursor{ID: 9}},
+			[]string{"id > $1"}, "id ASC", []interface{}{int64(9), 11},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newSelect("List", "id", "t")
+			if err := b.paginate(tt.page, tt.sortColumns); err != nil {
+				t.Fatal(err)
+			}
+			query, args, err := b.query()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "-- name: List :many\nSELECT id FROM t"
+			if len(tt.wantWhere) > 0 {
+				want += "\nWHERE " + strings.Join(tt.wantWhere, "\nAND ")
+			}
+			want += "\nORDER BY " + tt.wantOrderBy + "\nLIMIT $" + strconv.Itoa(len(tt.wantArgs))
+			if query != want {
+				t.Errorf("query =\n%s\nwant\n%s", query, want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPaginateUnknownField(t *testing.T) {
+	b := newSelect("List", "id", "authors")
+	if err := b.paginate(Page{Order: Order{Field: SortByEmail}, Limit: 10}, authorSortColumns); err == nil {
+		t.Error("got no error sorting authors by email")
+	}
+}
+
+func TestFilteredPage(t *testing.T) {
+	name := "ann"
+	b := newSelect("ListAgents", "id, name", "agents")
+	AgentFilter{NameContains: &name}.apply(b)
+	page := Page{Order: Order{Field: SortByEmail, Desc: true}, Limit: 2, HasAfter: true, After: Cursor{Key: "a@example.com", ID: 3}}
+	if err := b.paginate(page, agentSortColumns); err != nil {
+		t.Fatal(err)
+	}
+	query, args, err := b.query()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `-- name: ListAgents :many
+SELECT id, name FROM agents
+WHERE deleted_at IS NULL
+AND strpos(lower(name), lower($1::text)) > 0
+AND (email, id) < ($2::text, $3::bigint)
+ORDER BY email DESC, id DESC
+LIMIT $4`
+	if query != want {
+		t.Errorf("query =\n%s\nwant\n%s", query, want)
+	}
+	if wantArgs := []interface{}{"ann", "a@example.com", int64(3), 3}; !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %#v, want %#v", args, wantArgs)
+	}
+}
+
+func TestPlaceholderMismatch(t *testing.T) {
+	tests := []struct {
+		cond string
+		args []interface{}
+	}{
+		{"a = ?", nil},
+		{"a = ?", []interface{}{1, 2}},
+		{"a = ? AND b = ?", []interface{}{1}},
+		{"a IS NULL", []interface{}{1}},
+	}
+	for _, tt := range tests {
+		b := newSelect("List", "id", "t")
+		b.where("b = ?", 1)
+		b.where(tt.cond, tt.args...)
+		b.where("c = ?", 2)
+		if _, _, err := b.count(); err == nil {
+			t.Errorf("%q with %d arguments: count got no error", tt.cond, len(tt.args))
+		}
+		if err := b.paginate(Page{Limit: 10}, map[SortField]string{"": "id"}); err != nil {
+			t.Fatal(err)
+		}
+		if query, _, err := b.query(); err == nil {
+			t.Errorf("%q with %d arguments: query = %q, want an error", tt.cond, len(tt.args), query)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package pg
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+)
+
+// Entities of the recorded changes.
+const (
+	EntityAgent  = "agent"
+	EntityAuthor = "author"
+	EntityBook   = "book"
+)
+
+// Operations of the recorded changes.
+const (
+	OpCreate  = "create"
+	OpUpdate  = "update"
+	OpDelete  = "delete"
+	OpRestore = "restore"
+)
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor recorded in the changes
+// made with it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx, or an empty string.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// Change is a change of an entity, recorded as an outbox event and an audit
+// entry in the transaction making it. Before and After are JSON snapshots of
+// the entity, Before is nil for created and restored entities and After for
+// deleted ones.
+type Change struct {
+	Entity   string
+	EntityID int64
+	Op       string
+	Before   json.RawMessage
+	After    json.RawMessage
+	Actor    sql.NullString
+}
+
+// OutboxEvent returns the arguments of the CreateOutboxEvent query.
+func (c Change) OutboxEvent() CreateOutboxEventParams {
+	return CreateOutboxEventParams{
+		Entity:   c.Entity,
+		EntityID: c.EntityID,
+		Op:       c.Op,
+		Before:   c.Before,
+		After:    c.After,
+		Actor:    c.Actor,
+	}
+}
+
+// AuditEntry returns the arguments of the CreateAuditEntry query.
+func (c Change) AuditEntry() CreateAuditEntryParams {
+	return CreateAuditEntryParams{
+		Entity:   c.Entity,
+		EntityID: c.EntityID,
+		Op:       c.Op,
+		Actor:    c.Actor,
+		Changes:  c.diff(),
+	}
+}
+
+// FieldChange is the change of a single field of an entity. Old and New are
+// JSON values, null for the fields of created and deleted entities.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// diff returns the JSON array of the FieldChanges between the snapshots,
+// ordered by field. The id never changes and is left out.
+func (c Change) diff() json.RawMessage {
+	before, after := fields(c.Before), fields(c.After)
+	names := make(map[string]bool)
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+	delete(names, "id")
+	changes := []FieldChange{}
+	for name := range names {
+		o, n := before[name], after[name]
+		if bytes.Equal(o, n) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: name, Old: orNull(o), New: orNull(n)})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return marshalState(changes)
+}
+
+// fields splits a snapshot into its fields.
+func fields(snapshot json.RawMessage) map[string]json.RawMessage {
+	var m map[string]json.RawMessage
+	if snapshot != nil {
+		// the snapshots are always objects, see marshalState
+		json.Unmarshal(snapshot, &m)
+	}
+	return m
+}
+
+var null = json.RawMessage("null")
+
+func orNull(v json.RawMessage) json.RawMessage {
+	if v == nil {
+		return null
+	}
+	return v
+}
+
+// The states below are the JSON snapshots of the entities recorded as the
+// before and after of the changes.
+
+type agentState struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type authorState struct {
+	ID      int64   `json:"id"`
+	Name    string  `json:"name"`
+	Website *string `json:"website"`
+	AgentID int64   `json:"agentID"`
+}
+
+type bookState struct {
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Cover       string  `json:"cover"`
+	AuthorIDs   []int64 `json:"authorIDs"`
+}
+
+// NewAgentChange returns the change of an agent made with ctx. A nil before
+// or after stands for a created, or restored, or deleted agent.
+func NewAgentChange(ctx context.Context, op string, before, after *Agent) Change {
+	state := func(a *Agent) json.RawMessage {
+		if a == nil {
+			return nil
+		}
+		return marshalState(agentState{ID: a.ID, Name: a.Name, Email: a.Email})
+	}
+	var id int64
+	if before != nil {
+		id = before.ID
+	} else {
+		id = after.ID
+	}
+	return newChange(ctx, EntityAgent, id, op, state(before), state(after))
+}
+
+// NewAuthorChange returns the change of an author made with ctx. A nil
+// before or after stands for a created, or restored, or deleted author.
+func NewAuthorChange(ctx context.Context, op string, before, after *Author) Change {
+	state := func(a *Author) json.RawMessage {
+		if a == nil {
+			return nil
+		}
+		s := authorState{ID: a.ID, Name: a.Name, AgentID: a.AgentID}
+		if a.Website.Valid {
+			s.Website = &a.Website.String
+		}
+		return marshalState(s)
+	}
+	var id int64
+	if before != nil {
+		id = before.ID
+	} else {
+		id = after.ID
+	}
+	return newChange(ctx, EntityAuthor, id, op, state(before), state(after))
+}
+
+// BookState is a book together with the IDs of its authors.
+type BookState struct {
+	Book
+	AuthorIDs []int64
+}
+
+// AddedAuthorIDs returns the IDs of the authors of after which are not
+// authors of before.
+func AddedAuthorIDs(before, after *BookState) []int64 {
+	previous := make(map[int64]bool, len(before.AuthorIDs))
+	for _, id := range before.AuthorIDs {
+		previous[id] = true
+	}
+	var added []int64
+	for _, id := range after.AuthorIDs {
+		if !previous[id] {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// NewBookChange returns the change of a book made with ctx. A nil before or
+// after stands for a created, or restored, or deleted book.
+func NewBookChange(ctx context.Context, op string, before, after *BookState) Change {
+	state := func(b *BookState) json.RawMessage {
+		if b == nil {
+			return nil
+		}
+		authorIDs := append([]int64{}, b.AuthorIDs...)
+		sort.Slice(authorIDs, func(i, j int) bool { return authorIDs[i] < authorIDs[j] })
+		return marshalState(bookState{
+			ID:          b.ID,
+			Title:       b.Title,
+			Description: b.Description,
+			Cover:       b.Cover,
+			AuthorIDs:   authorIDs,
+		})
+	}
+	var id int64
+	if before != nil {
+		id = before.ID
+	} else {
+		id = after.ID
+	}
+	return newChange(ctx, EntityBook, id, op, state(before), state(after))
+}
+
+func newChange(ctx context.Context, entity string, id int64, op string, before, after json.RawMessage) Change {
+	actor := ActorFromContext(ctx)
+	return Change{
+		Entity:   entity,
+		EntityID: id,
+		Op:       op,
+		Before:   before,
+		After:    after,
+		Actor:    sql.NullString{String: actor, Valid: actor != ""},
+	}
+}
+
+// marshalState encodes a state, which consists of plain values and always
+// marshals.
+func marshalState(v interface{}) json.RawMessage {
+	b, _ := json.Marshal(v)
+	return b
+}
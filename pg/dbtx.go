@@ -0,0 +1,30 @@
+package pg
+
+import "strings"
+
+// DBTXMiddleware wraps the DBTX the queries are executed with, e.g. to
+// instrument them.
+type DBTXMiddleware func(DBTX) DBTX
+
+// wrapDBTX applies the middlewares to dbtx, the first one being the
+// outermost.
+func wrapDBTX(dbtx DBTX, middlewares []DBTXMiddleware) DBTX {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		dbtx = middlewares[i](dbtx)
+	}
+	return dbtx
+}
+
+// QueryName returns the name of a query from the "-- name: X :kind" comment
+// sqlc and the query builder put in front of every query, or "unknown".
+func QueryName(query string) string {
+	const prefix = "-- name: "
+	if !strings.HasPrefix(query, prefix) {
+		return "unknown"
+	}
+	line := query[len(prefix):]
+	if i := strings.IndexAny(line, " \n"); i >= 0 {
+		line = line[:i]
+	}
+	return line
+}
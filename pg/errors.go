@@ -0,0 +1,139 @@
+package pg
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// Errors returned by the Repository. Use errors.Is to test for them, as they
+// are usually wrapped in a ConstraintError carrying more details.
+var (
+	// ErrNotFound is returned when the requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a change conflicts with the existing
+	// data, e.g. a duplicate value or a delete of a referenced entity.
+	ErrConflict = errors.New("conflict")
+	// ErrInvalidReference is returned when an entity references an entity
+	// which does not exist.
+	ErrInvalidReference = errors.New("invalid reference")
+	// ErrInvalidInput is returned when a required value is missing.
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+// postgres error codes handled by the package.
+const (
+	undefinedTable      = pq.ErrorCode("42P01")
+	notNullViolation    = pq.ErrorCode("23502")
+	foreignKeyViolation = pq.ErrorCode("23503")
+	uniqueViolation     = pq.ErrorCode("23505")
+)
+
+// ConstraintError describes a violated database constraint. It unwraps to
+// ErrConflict, ErrInvalidReference or ErrInvalidInput.
+type ConstraintError struct {
+	Err        error
+	Message    string
+	Detail     string
+	Constraint string
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Message)
+}
+
+// Unwrap returns the kind of the error.
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// VersionError is returned by the updates when the entity is no longer at
+// the version the change was based on. It unwraps to ErrConflict.
+type VersionError struct {
+	Entity   string
+	ID       int64
+	Expected int32
+	Current  int32
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%v: %s %d is at version %d, not %d", ErrConflict, e.Entity, e.ID, e.Current, e.Expected)
+}
+
+// Unwrap returns ErrConflict.
+func (e *VersionError) Unwrap() error {
+	return ErrConflict
+}
+
+// checkVersion returns a VersionError unless the entity is at the expected
+// version, which is not checked when nil.
+func checkVersion(entity string, id int64, expected *int32, current int32) error {
+	if expected != nil && *expected != current {
+		return &VersionError{Entity: entity, ID: id, Expected: *expected, Current: current}
+	}
+	return nil
+}
+
+// translateError maps sql.ErrNoRows and constraint violations reported by
+// postgres to the errors of the Repository. Other errors are returned as is.
+func translateError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	var kind error
+	switch pqErr.Code {
+	case notNullViolation:
+		kind = ErrInvalidInput
+	case foreignKeyViolation:
+		kind = ErrInvalidReference
+	case uniqueViolation:
+		kind = ErrConflict
+	default:
+		return err
+	}
+	return &ConstraintError{
+		Err:        kind,
+		Message:    pqErr.Message,
+		Detail:     pqErr.Detail,
+		Constraint: pqErr.Constraint,
+	}
+}
+
+// translateDeleteError is translateError for DELETE statements, where a
+// foreign key violation means that the row is still referenced.
+func translateDeleteError(err error) error {
+	err = translateError(err)
+	var cErr *ConstraintError
+	if errors.As(err, &cErr) && cErr.Err == ErrInvalidReference {
+		cErr.Err = ErrConflict
+	}
+	return err
+}
+
+// referenceError is the error of a row referencing a missing, or deleted,
+// row, worded like the foreign key violations reported by postgres.
+func referenceError(table, constraint, detail string) error {
+	return &ConstraintError{
+		Err:        ErrInvalidReference,
+		Message:    fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint),
+		Detail:     detail,
+		Constraint: constraint,
+	}
+}
+
+// referencedError is the error of deleting a row which is still referenced
+// from another table, worded like the violations of ON DELETE RESTRICT.
+func referencedError(table, referencingTable, constraint string, id int64) error {
+	return &ConstraintError{
+		Err:        ErrConflict,
+		Message:    fmt.Sprintf("update or delete on table %q violates foreign key constraint %q on table %q", table, constraint, referencingTable),
+		Detail:     fmt.Sprintf("Key (id)=(%d) is still referenced from table %q.", id, referencingTable),
+		Constraint: constraint,
+	}
+}
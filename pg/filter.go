@@ -0,0 +1,295 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// SortField is a column the list queries can be ordered by. The zero value
+// selects the default order: name for agents and authors, title for books.
+type SortField string
+
+// Sort fields supported by the list queries.
+const (
+	SortByID    SortField = "id"
+	SortByName  SortField = "name"
+	SortByEmail SortField = "email"
+	SortByTitle SortField = "title"
+)
+
+// Order is the sort order of a list query. Ties are broken by id.
+type Order struct {
+	Field SortField
+	Desc  bool
+}
+
+var (
+	agentSortColumns = map[SortField]string{
+		"":          "name",
+		SortByID:    "id",
+		SortByName:  "name",
+		SortByEmail: "email",
+	}
+	authorSortColumns = map[SortField]string{
+		"":         "name",
+		SortByID:   "id",
+		SortByName: "name",
+	}
+	bookSortColumns = map[SortField]string{
+		"":          "title",
+		SortByID:    "id",
+		SortByTitle: "title",
+	}
+)
+
+// AgentFilter restricts the agents returned by ListAgents.
+type AgentFilter struct {
+	NameContains *string
+	EmailDomain  *string
+	// IncludeDeleted includes the deleted agents.
+	IncludeDeleted bool
+}
+
+func (f AgentFilter) apply(b *selectBuilder) {
+	if !f.IncludeDeleted {
+		b.where("deleted_at IS NULL")
+	}
+	if f.NameContains != nil {
+		b.where("strpos(lower(name), lower(?::text)) > 0", *f.NameContains)
+	}
+	if f.EmailDomain != nil {
+		b.where("lower(split_part(email, '@', 2)) = lower(?::text)", *f.EmailDomain)
+	}
+}
+
+// AuthorFilter restricts the authors returned by ListAuthors.
+type AuthorFilter struct {
+	NameContains *string
+	HasWebsite   *bool
+	AgentIDs     []int64
+	// IncludeDeleted includes the deleted authors.
+	IncludeDeleted bool
+}
+
+func (f AuthorFilter) apply(b *selectBuilder) {
+	if !f.IncludeDeleted {
+		b.where("deleted_at IS NULL")
+	}
+	if f.NameContains != nil {
+		b.where("strpos(lower(name), lower(?::text)) > 0", *f.NameContains)
+	}
+	if f.HasWebsite != nil {
+		b.where("(website IS NOT NULL) = ?", *f.HasWebsite)
+	}
+	if f.AgentIDs != nil {
+		b.where("agent_id = ANY(?::bigint[])", pq.Array(f.AgentIDs))
+	}
+}
+
+// BookFilter restricts the books returned by ListBooks.
+type BookFilter struct {
+	TitleContains       *string
+	DescriptionContains *string
+	AuthorIDs           []int64
+	// IncludeDeleted includes the deleted books.
+	IncludeDeleted bool
+}
+
+func (f BookFilter) apply(b *selectBuilder) {
+	if !f.IncludeDeleted {
+		b.where("deleted_at IS NULL")
+	}
+	if f.TitleContains != nil {
+		b.where("strpos(lower(title), lower(?::text)) > 0", *f.TitleContains)
+	}
+	if f.DescriptionContains != nil {
+		b.where("strpos(lower(description), lower(?::text)) > 0", *f.DescriptionContains)
+	}
+	if f.AuthorIDs != nil {
+		b.where("id IN (SELECT book_id FROM book_authors WHERE author_id = ANY(?::bigint[]))", pq.Array(f.AuthorIDs))
+	}
+}
+
+// ListAgents returns a page of the agents matching filter.
+func (q *Queries) ListAgents(ctx context.Context, filter AgentFilter, page Page) ([]Agent, error) {
+	b := newSelect("ListAgents", "id, name, email, deleted_at, version", "agents")
+	filter.apply(b)
+	if err := b.paginate(page, agentSortColumns); err != nil {
+		return nil, err
+	}
+	query, args, err := b.query()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Agent
+	for rows.Next() {
+		var i Agent
+		if err := rows.Scan(&i.ID, &i.Name, &i.Email, &i.DeletedAt, &i.Version); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CountAgents returns the number of agents matching filter.
+func (q *Queries) CountAgents(ctx context.Context, filter AgentFilter) (int64, error) {
+	b := newSelect("CountAgents", "", "agents")
+	filter.apply(b)
+	query, args, err := b.count()
+	if err != nil {
+		return 0, err
+	}
+	row := q.db.QueryRowContext(ctx, query, args...)
+	var count int64
+	err = row.Scan(&count)
+	return count, err
+}
+
+// ListAuthors returns a page of the authors matching filter.
+func (q *Queries) ListAuthors(ctx context.Context, filter AuthorFilter, page Page) ([]Author, error) {
+	b := newSelect("ListAuthors", "id, name, website, agent_id, deleted_at, version", "authors")
+	filter.apply(b)
+	if err := b.paginate(page, authorSortColumns); err != nil {
+		return nil, err
+	}
+	query, args, err := b.query()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Website,
+			&i.AgentID,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CountAuthors returns the number of authors matching filter.
+func (q *Queries) CountAuthors(ctx context.Context, filter AuthorFilter) (int64, error) {
+	b := newSelect("CountAuthors", "", "authors")
+	filter.apply(b)
+	query, args, err := b.count()
+	if err != nil {
+		return 0, err
+	}
+	row := q.db.QueryRowContext(ctx, query, args...)
+	var count int64
+	err = row.Scan(&count)
+	return count, err
+}
+
+// ListBooks returns a page of the books matching filter.
+func (q *Queries) ListBooks(ctx context.Context, filter BookFilter, page Page) ([]Book, error) {
+	b := newSelect("ListBooks", "id, title, description, cover, deleted_at, version", "books")
+	filter.apply(b)
+	if err := b.paginate(page, bookSortColumns); err != nil {
+		return nil, err
+	}
+	query, args, err := b.query()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CountBooks returns the number of books matching filter.
+func (q *Queries) CountBooks(ctx context.Context, filter BookFilter) (int64, error) {
+	b := newSelect("CountBooks", "", "books")
+	filter.apply(b)
+	query, args, err := b.count()
+	if err != nil {
+		return 0, err
+	}
+	row := q.db.QueryRowContext(ctx, query, args...)
+	var count int64
+	err = row.Scan(&count)
+	return count, err
+}
+
+// agentSortKey returns the cursor key of an agent for the given sort field.
+func agentSortKey(a Agent, f SortField) string {
+	switch f {
+	case SortByID:
+		return ""
+	case SortByEmail:
+		return a.Email
+	default:
+		return a.Name
+	}
+}
+
+// authorSortKey returns the cursor key of an author for the given sort field.
+func authorSortKey(a Author, f SortField) string {
+	if f == SortByID {
+		return ""
+	}
+	return a.Name
+}
+
+// bookSortKey returns the cursor key of a book for the given sort field.
+func bookSortKey(b Book, f SortField) string {
+	if f == SortByID {
+		return ""
+	}
+	return b.Title
+}
@@ -0,0 +1,40 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+)
+
+// PoolCheck returns a health check which fails when the connection pool of
+// db is exhausted: all connections are in use and queries have been waiting
+// for a connection since the previous check.
+func PoolCheck(db *sql.DB) func(context.Context) error {
+	var mu sync.Mutex
+	var lastWaitCount int64
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stats := db.Stats()
+		waited := stats.WaitCount > lastWaitCount
+		lastWaitCount = stats.WaitCount
+		if stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections && waited {
+			return fmt.Errorf("connection pool exhausted: %d of %d connections in use", stats.InUse, stats.MaxOpenConnections)
+		}
+		return nil
+	}
+}
+
+// Check is a health check which fails unless all migrations embedded in the
+// binary have been applied.
+func (m *Migrator) Check(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version != m.Latest() {
+		return fmt.Errorf("database is at migration version %d, expected %d", version, m.Latest())
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package pg
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// migrationFS returns a file system holding the migration files of names,
+// each containing its name.
+func migrationFS(names ...string) fstest.MapFS {
+	fsys := make(fstest.MapFS)
+	for _, name := range names {
+		fsys["migrations/"+name] = &fstest.MapFile{Data: []byte("-- " + name)}
+	}
+	return fsys
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations(migrationFS(
+		"10_ten.up.sql", "10_ten.down.sql",
+		"2_two.down.sql", "2_two.up.sql",
+		"0001_create_tables.up.sql", "0001_create_tables.down.sql",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the versions are ordered numerically, not by file name
+	want := []Migration{
+		{Version: 1, Name: "create_tables", Up: "-- 0001_create_tables.up.sql", Down: "-- 0001_create_tables.down.sql"},
+		{Version: 2, Name: "two", Up: "-- 2_two.up.sql", Down: "-- 2_two.down.sql"},
+		{Version: 10, Name: "ten", Up: "-- 10_ten.up.sql", Down: "-- 10_ten.down.sql"},
+	}
+	if len(migrations) != len(want) {
+		t.Fatalf("migrations = %+v, want %+v", migrations, want)
+	}
+	for i := range want {
+		if migrations[i] != want[i] {
+			t.Errorf("migration %d = %+v, want %+v", i, migrations[i], want[i])
+		}
+	}
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{"missing down", []string{"1_a.up.sql", "1_a.down.sql", "2_b.up.sql"}, "missing up or down"},
+		{"missing up", []string{"1_a.down.sql"}, "missing up or down"},
+		{"duplicate version", []string{"1_a.up.sql", "1_a.down.sql", "1_b.up.sql", "1_b.down.sql"}, "is used by"},
+		{"duplicate file", []string{"1_a.up.sql", "1_a.down.sql", "01_a.up.sql"}, "several up files"},
+		{"no name", []string{"1.up.sql", "1.down.sql"}, "must be named"},
+		{"no direction", []string{"1_a.sql"}, "must end in"},
+		{"non-numeric version", []string{"one_a.up.sql", "one_a.down.sql"}, "invalid version"},
+		{"zero version", []string{"0_a.up.sql", "0_a.down.sql"}, "invalid version"},
+		{"negative version", []string{"-1_a.up.sql", "-1_a.down.sql"}, "invalid version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadMigrations(migrationFS(tt.files...))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// The embedded migrations are valid and numbered without gaps.
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, mig := range migrations {
+		if mig.Version != int64(i+1) {
+			t.Errorf("migration %d has version %d", i+1, mig.Version)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS book_authors;
+
+DROP TABLE IF EXISTS books;
+
+DROP TABLE IF EXISTS authors;
+
+DROP TABLE IF EXISTS agents;
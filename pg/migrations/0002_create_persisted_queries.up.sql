@@ -0,0 +1,11 @@
+-- only the imported queries are allowed in allowlist mode, those registered
+-- by clients in apq mode are pruned
+CREATE TABLE IF NOT EXISTS persisted_queries (
+    hash TEXT PRIMARY KEY,
+    query TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    imported BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS persisted_queries_registered_idx ON persisted_queries (created_at)
+WHERE NOT imported;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    entity TEXT NOT NULL,
+    entity_id BIGINT NOT NULL,
+    op TEXT NOT NULL,
+    before JSONB,
+    after JSONB,
+    actor TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (next_attempt_at, id)
+WHERE delivered_at IS NULL;
+
+-- the delivered events are pruned by the purge job
+CREATE INDEX IF NOT EXISTS outbox_events_delivered_idx ON outbox_events (delivered_at)
+WHERE delivered_at IS NOT NULL;
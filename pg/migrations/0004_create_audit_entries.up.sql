@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id BIGSERIAL PRIMARY KEY,
+    entity TEXT NOT NULL,
+    entity_id BIGINT NOT NULL,
+    op TEXT NOT NULL,
+    actor TEXT,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    changes JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_entries_entity_idx ON audit_entries (entity, entity_id, id);
+CREATE INDEX IF NOT EXISTS audit_entries_actor_idx ON audit_entries (actor, id);
+CREATE INDEX IF NOT EXISTS audit_entries_changed_at_idx ON audit_entries (changed_at);
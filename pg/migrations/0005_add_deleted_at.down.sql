@@ -0,0 +1,9 @@
+-- the soft-deleted rows would reappear without the column
+DELETE FROM books WHERE deleted_at IS NOT NULL;
+DELETE FROM authors WHERE deleted_at IS NOT NULL;
+DELETE FROM agents WHERE deleted_at IS NOT NULL
+AND NOT EXISTS (SELECT 1 FROM authors WHERE authors.agent_id = agents.id);
+
+ALTER TABLE books DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE authors DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE agents DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,10 @@
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE authors ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE books ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS agents_deleted_at_idx ON agents (deleted_at)
+WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS authors_deleted_at_idx ON authors (deleted_at)
+WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS books_deleted_at_idx ON books (deleted_at)
+WHERE deleted_at IS NOT NULL;
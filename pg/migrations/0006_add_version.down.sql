@@ -0,0 +1,3 @@
+ALTER TABLE books DROP COLUMN IF EXISTS version;
+ALTER TABLE authors DROP COLUMN IF EXISTS version;
+ALTER TABLE agents DROP COLUMN IF EXISTS version;
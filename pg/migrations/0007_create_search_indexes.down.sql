@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS books_search_idx;
+DROP INDEX IF EXISTS authors_search_idx;
+DROP INDEX IF EXISTS agents_search_idx;
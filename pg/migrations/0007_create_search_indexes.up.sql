@@ -0,0 +1,9 @@
+-- the search vectors are computed by the search query and indexed as
+-- expressions, rather than stored in generated columns which every query
+-- selecting the entities would have to leave out
+CREATE INDEX IF NOT EXISTS agents_search_idx ON agents
+USING GIN (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS authors_search_idx ON authors
+USING GIN (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS books_search_idx ON books
+USING GIN ((setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', description), 'B')));
@@ -4,19 +4,25 @@ package pg
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
 type Agent struct {
-	ID    int64
-	Name  string
-	Email string
+	ID        int64
+	Name      string
+	Email     string
+	DeletedAt sql.NullTime
+	Version   int32
 }
 
 type Author struct {
-	ID      int64
-	Name    string
-	Website sql.NullString
-	AgentID int64
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	AgentID   int64
+	DeletedAt sql.NullTime
+	Version   int32
 }
 
 type Book struct {
@@ -24,6 +30,8 @@ type Book struct {
 	Title       string
 	Description string
 	Cover       string
+	DeletedAt   sql.NullTime
+	Version     int32
 }
 
 type BookAuthor struct {
@@ -31,3 +39,35 @@ type BookAuthor struct {
 	BookID   int64
 	AuthorID int64
 }
+
+type PersistedQuery struct {
+	Hash      string
+	Query     string
+	CreatedAt time.Time
+	Imported  bool
+}
+
+type OutboxEvent struct {
+	ID            int64
+	Entity        string
+	EntityID      int64
+	Op            string
+	Before        json.RawMessage
+	After         json.RawMessage
+	Actor         sql.NullString
+	CreatedAt     time.Time
+	Attempts      int32
+	NextAttemptAt time.Time
+	LastError     sql.NullString
+	DeliveredAt   sql.NullTime
+}
+
+type AuditEntry struct {
+	ID        int64
+	Entity    string
+	EntityID  int64
+	Op        string
+	Actor     sql.NullString
+	ChangedAt time.Time
+	Changes   json.RawMessage
+}
@@ -0,0 +1,11 @@
+package pg
+
+// Node is an entity identified by a global ID, an Agent, an Author or a
+// Book.
+type Node interface {
+	isNode()
+}
+
+func (Agent) isNode()  {}
+func (Author) isNode() {}
+func (Book) isNode()   {}
@@ -0,0 +1,326 @@
+package pg
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is the number of items returned when neither first nor last
+// is specified.
+const DefaultPageSize = 100
+
+// MaxPageSize is the largest number of items which can be requested with
+// first or last.
+const MaxPageSize = 1000
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded, or belongs
+// to another order than the one it is used with. It wraps ErrInvalidInput.
+var ErrInvalidCursor = fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+
+// Cursor identifies a position in a list ordered by a sort key (name or
+// title) and id. It records the order of the list, so that it cannot be used
+// with another one.
+type Cursor struct {
+	Order Order
+	Key   string
+	ID    int64
+}
+
+// String returns the opaque representation of the cursor.
+func (c Cursor) String() string {
+	dir := "asc"
+	if c.Order.Desc {
+		dir = "desc"
+	}
+	s := strconv.FormatInt(c.ID, 10) + ":" + string(c.Order.Field) + ":" + dir + ":" + c.Key
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeCursor parses the opaque representation of a cursor.
+func DecodeCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	parts := strings.SplitN(string(b), ":", 4)
+	if len(parts) != 4 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	c := Cursor{Order: Order{Field: SortField(parts[1])}, Key: parts[3], ID: id}
+	switch parts[2] {
+	case "asc":
+	case "desc":
+		c.Order.Desc = true
+	default:
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Page is a validated set of Relay connection arguments together with the
+// sort order they apply to. It is comparable so it can be used as part of a
+// dataloader key.
+type Page struct {
+	Order     Order
+	Limit     int
+	Reverse   bool
+	HasAfter  bool
+	After     Cursor
+	HasBefore bool
+	Before    Cursor
+}
+
+// NewPage validates the Relay connection arguments of a list sorted in order
+// and returns a Page. The cursors must have been returned for the same
+// order.
+func NewPage(order Order, first *int, after *string, last *int, before *string) (Page, error) {
+	p := Page{Order: order, Limit: DefaultPageSize}
+	if first != nil && last != nil {
+		return Page{}, fmt.Errorf("%w: first and last must not be used together", ErrInvalidInput)
+	}
+	if first != nil {
+		if *first < 0 {
+			return Page{}, fmt.Errorf("%w: first must not be negative", ErrInvalidInput)
+		}
+		if *first > MaxPageSize {
+			return Page{}, fmt.Errorf("%w: first must not exceed %d", ErrInvalidInput, MaxPageSize)
+		}
+		p.Limit = *first
+	}
+	if last != nil {
+		if *last < 0 {
+			return Page{}, fmt.Errorf("%w: last must not be negative", ErrInvalidInput)
+		}
+		if *last > MaxPageSize {
+			return Page{}, fmt.Errorf("%w: last must not exceed %d", ErrInvalidInput, MaxPageSize)
+		}
+		p.Limit = *last
+		p.Reverse = true
+	}
+	if after != nil {
+		c, err := decodePageCursor(*after, order)
+		if err != nil {
+			return Page{}, err
+		}
+		p.HasAfter, p.After = true, c
+	}
+	if before != nil {
+		c, err := decodePageCursor(*before, order)
+		if err != nil {
+			return Page{}, err
+		}
+		p.HasBefore, p.Before = true, c
+	}
+	return p, nil
+}
+
+// decodePageCursor decodes a cursor of a list sorted in order.
+func decodePageCursor(s string, order Order) (Cursor, error) {
+	c, err := DecodeCursor(s)
+	if err != nil {
+		return Cursor{}, err
+	}
+	if c.Order != order {
+		return Cursor{}, fmt.Errorf("%w: the cursor belongs to another order", ErrInvalidCursor)
+	}
+	return c, nil
+}
+
+// rowLimit is the number of rows page queries fetch: one more than the page
+// size, so that the existence of a further page can be detected.
+func (p Page) rowLimit() int32 {
+	return int32(p.Limit + 1)
+}
+
+// AuthorsByAgentIDsParams returns the arguments of the
+// ListAuthorsByAgentIDsPage query.
+func (p Page) AuthorsByAgentIDsParams(agentIDs []int64) ListAuthorsByAgentIDsPageParams {
+	return ListAuthorsByAgentIDsPageParams{
+		AgentIds:  agentIDs,
+		HasAfter:  p.HasAfter,
+		AfterKey:  p.After.Key,
+		AfterID:   p.After.ID,
+		HasBefore: p.HasBefore,
+		BeforeKey: p.Before.Key,
+		BeforeID:  p.Before.ID,
+		Reverse:   p.Reverse,
+		RowLimit:  p.rowLimit(),
+	}
+}
+
+// AuthorsByBookIDsParams returns the arguments of the
+// ListAuthorsByBookIDsPage query.
+func (p Page) AuthorsByBookIDsParams(bookIDs []int64) ListAuthorsByBookIDsPageParams {
+	return ListAuthorsByBookIDsPageParams{
+		BookIds:   bookIDs,
+		HasAfter:  p.HasAfter,
+		AfterKey:  p.After.Key,
+		AfterID:   p.After.ID,
+		HasBefore: p.HasBefore,
+		BeforeKey: p.Before.Key,
+		BeforeID:  p.Before.ID,
+		Reverse:   p.Reverse,
+		RowLimit:  p.rowLimit(),
+	}
+}
+
+// BooksByAuthorIDsParams returns the arguments of the
+// ListBooksByAuthorIDsPage query.
+func (p Page) BooksByAuthorIDsParams(authorIDs []int64) ListBooksByAuthorIDsPageParams {
+	return ListBooksByAuthorIDsPageParams{
+		AuthorIds: authorIDs,
+		HasAfter:  p.HasAfter,
+		AfterKey:  p.After.Key,
+		AfterID:   p.After.ID,
+		HasBefore: p.HasBefore,
+		BeforeKey: p.Before.Key,
+		BeforeID:  p.Before.ID,
+		Reverse:   p.Reverse,
+		RowLimit:  p.rowLimit(),
+	}
+}
+
+// PageInfo describes the position of a page within a connection.
+type PageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
+}
+
+// AgentEdge is an agent together with its cursor.
+type AgentEdge struct {
+	Cursor string
+	Node   Agent
+}
+
+// AgentConnection is a page of agents.
+type AgentConnection struct {
+	Edges      []AgentEdge
+	PageInfo   PageInfo
+	TotalCount int64
+}
+
+// AuthorEdge is an author together with its cursor.
+type AuthorEdge struct {
+	Cursor string
+	Node   Author
+}
+
+// AuthorConnection is a page of authors.
+type AuthorConnection struct {
+	Edges      []AuthorEdge
+	PageInfo   PageInfo
+	TotalCount int64
+}
+
+// BookEdge is a book together with its cursor.
+type BookEdge struct {
+	Cursor string
+	Node   Book
+}
+
+// BookConnection is a page of books.
+type BookConnection struct {
+	Edges      []BookEdge
+	PageInfo   PageInfo
+	TotalCount int64
+}
+
+// NewAgentConnection builds a connection from the rows returned by a page
+// query executed with the arguments of page.
+func NewAgentConnection(page Page, items []Agent, totalCount int64) *AgentConnection {
+	n, pageInfo := page.window(len(items))
+	conn := &AgentConnection{
+		Edges:      make([]AgentEdge, n),
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}
+	for i := range conn.Edges {
+		item := items[page.index(i, n)]
+		conn.Edges[i] = AgentEdge{
+			Cursor: Cursor{Order: page.Order, Key: agentSortKey(item, page.Order.Field), ID: item.ID}.String(),
+			Node:   item,
+		}
+	}
+	if n > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[n-1].Cursor
+	}
+	return conn
+}
+
+// NewAuthorConnection builds a connection from the rows returned by a page
+// query executed with the arguments of page.
+func NewAuthorConnection(page Page, items []Author, totalCount int64) *AuthorConnection {
+	n, pageInfo := page.window(len(items))
+	conn := &AuthorConnection{
+		Edges:      make([]AuthorEdge, n),
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}
+	for i := range conn.Edges {
+		item := items[page.index(i, n)]
+		conn.Edges[i] = AuthorEdge{
+			Cursor: Cursor{Order: page.Order, Key: authorSortKey(item, page.Order.Field), ID: item.ID}.String(),
+			Node:   item,
+		}
+	}
+	if n > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[n-1].Cursor
+	}
+	return conn
+}
+
+// NewBookConnection builds a connection from the rows returned by a page
+// query executed with the arguments of page.
+func NewBookConnection(page Page, items []Book, totalCount int64) *BookConnection {
+	n, pageInfo := page.window(len(items))
+	conn := &BookConnection{
+		Edges:      make([]BookEdge, n),
+		PageInfo:   pageInfo,
+		TotalCount: totalCount,
+	}
+	for i := range conn.Edges {
+		item := items[page.index(i, n)]
+		conn.Edges[i] = BookEdge{
+			Cursor: Cursor{Order: page.Order, Key: bookSortKey(item, page.Order.Field), ID: item.ID}.String(),
+			Node:   item,
+		}
+	}
+	if n > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[n-1].Cursor
+	}
+	return conn
+}
+
+// window returns the number of edges in the page and the page info flags for
+// a query that returned the given number of rows.
+func (p Page) window(rows int) (int, PageInfo) {
+	n := rows
+	if n > p.Limit {
+		n = p.Limit
+	}
+	more := rows > p.Limit
+	if p.Reverse {
+		return n, PageInfo{HasNextPage: p.HasBefore, HasPreviousPage: more}
+	}
+	return n, PageInfo{HasNextPage: more, HasPreviousPage: p.HasAfter}
+}
+
+// index maps the position of an edge to the position of its row. Rows of
+// reverse pages are returned in descending order.
+func (p Page) index(i, n int) int {
+	if p.Reverse {
+		return n - 1 - i
+	}
+	return i
+}
@@ -0,0 +1,117 @@
+package pg
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestNewPageLimit(t *testing.T) {
+	n := func(i int) *int { return &i }
+	tests := []struct {
+		name        string
+		first, last *int
+		limit       int
+		err         bool
+	}{
+		{name: "default", limit: DefaultPageSize},
+		{name: "first", first: n(10), limit: 10},
+		{name: "last", last: n(10), limit: 10},
+		{name: "max first", first: n(MaxPageSize), limit: MaxPageSize},
+		{name: "max last", last: n(MaxPageSize), limit: MaxPageSize},
+		{name: "first too large", first: n(MaxPageSize + 1), err: true},
+		{name: "last too large", last: n(MaxPageSize + 1), err: true},
+		{name: "first overflowing the row limit", first: n(2147483647), err: true},
+		{name: "negative first", first: n(-1), err: true},
+		{name: "negative last", last: n(-1), err: true},
+		{name: "first and last", first: n(1), last: n(1), err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPage(Order{}, tt.first, nil, tt.last, nil)
+			if tt.err {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Fatalf("got %v, want %v", err, ErrInvalidInput)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Limit != tt.limit || p.rowLimit() != int32(tt.limit+1) {
+				t.Errorf("limit = %d, row limit = %d, want %d", p.Limit, p.rowLimit(), tt.limit)
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	for _, c := range []Cursor{
+		{ID: 1},
+		{Order: Order{Field: SortByName}, Key: "Ann", ID: 2},
+		{Order: Order{Field: SortByEmail, Desc: true}, Key: "a:b@example.com", ID: 3},
+		{Order: AuditOrder, ID: 4},
+		{Order: SearchOrder, Key: "0.5:book", ID: 5},
+	} {
+		got, err := DecodeCursor(c.String())
+		if err != nil {
+			t.Errorf("%+v: %v", c, err)
+			continue
+		}
+		if got != c {
+			t.Errorf("decoded %+v, want %+v", got, c)
+		}
+	}
+}
+
+func TestNewPageCursor(t *testing.T) {
+	encode := func(s string) *string {
+		s = base64.RawURLEncoding.EncodeToString([]byte(s))
+		return &s
+	}
+	byName := Order{Field: SortByName}
+	cursor := func(order Order) *string {
+		s := Cursor{Order: order, Key: "Ann", ID: 2}.String()
+		return &s
+	}
+	tests := []struct {
+		name   string
+		order  Order
+		cursor *string
+		err    bool
+	}{
+		{name: "same order", order: byName, cursor: cursor(byName)},
+		{name: "default order", cursor: cursor(Order{})},
+		{name: "other field", order: byName, cursor: cursor(Order{Field: SortByEmail}), err: true},
+		{name: "other direction", order: byName, cursor: cursor(Order{Field: SortByName, Desc: true}), err: true},
+		{name: "default order cursor", order: byName, cursor: cursor(Order{}), err: true},
+		{name: "search cursor", order: byName, cursor: cursor(SearchOrder), err: true},
+		{name: "not base64", cursor: func() *string { s := "!"; return &s }(), err: true},
+		{name: "previous format", cursor: encode("2:Ann"), err: true},
+		{name: "non-numeric id", cursor: encode("x:name:asc:Ann"), order: byName, err: true},
+		{name: "unknown direction", cursor: encode("2:name:up:Ann"), order: byName, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, args := range [][2]*string{{tt.cursor, nil}, {nil, tt.cursor}} {
+				p, err := NewPage(tt.order, nil, args[0], nil, args[1])
+				if tt.err {
+					if !errors.Is(err, ErrInvalidCursor) || !errors.Is(err, ErrInvalidInput) {
+						t.Errorf("got %v, want %v", err, ErrInvalidCursor)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				c := p.After
+				if args[1] != nil {
+					c = p.Before
+				}
+				if p.Order != tt.order || c.Key != "Ann" || c.ID != 2 {
+					t.Errorf("page %+v, want the order and the position of the cursor", p)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+)
+
+// PersistedQueryStore keeps persisted queries in the persisted_queries
+// table. The queries imported from manifests are kept apart from those
+// registered by clients, of which only the most recent are kept.
+type PersistedQueryStore struct {
+	q    *Queries
+	size int
+}
+
+// NewPersistedQueryStore returns a PersistedQueryStore using db, wrapped by
+// the middlewares, which keeps size registered queries. A size of 0 keeps
+// all of them.
+func NewPersistedQueryStore(db *sql.DB, size int, middlewares ...DBTXMiddleware) *PersistedQueryStore {
+	return &PersistedQueryStore{q: New(wrapDBTX(db, middlewares)), size: size}
+}
+
+// Get returns the query with the given hash, registered or imported, or
+// ErrNotFound.
+func (s *PersistedQueryStore) Get(ctx context.Context, hash string) (string, error) {
+	query, err := s.q.GetPersistedQuery(ctx, hash)
+	if err != nil {
+		return "", translateError(err)
+	}
+	return query, nil
+}
+
+// GetImported returns the imported query with the given hash, or
+// ErrNotFound.
+func (s *PersistedQueryStore) GetImported(ctx context.Context, hash string) (string, error) {
+	query, err := s.q.GetImportedPersistedQuery(ctx, hash)
+	if err != nil {
+		return "", translateError(err)
+	}
+	return query, nil
+}
+
+// Put registers query under hash and removes the oldest registered queries
+// beyond the size of the store. Storing a hash again is a no-op.
+func (s *PersistedQueryStore) Put(ctx context.Context, hash, query string) error {
+	err := s.q.CreatePersistedQuery(ctx, CreatePersistedQueryParams{
+		Hash:  hash,
+		Query: query,
+	})
+	if err != nil {
+		return translateError(err)
+	}
+	if s.size > 0 {
+		_, err = s.q.PruneRegisteredPersistedQueries(ctx, int32(s.size))
+	}
+	return translateError(err)
+}
+
+// Import stores query under hash as an imported query, which is never
+// removed.
+func (s *PersistedQueryStore) Import(ctx context.Context, hash, query string) error {
+	return translateError(s.q.ImportPersistedQuery(ctx, ImportPersistedQueryParams{
+		Hash:  hash,
+		Query: query,
+	}))
+}
@@ -3,7 +3,9 @@ package pg
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	_ "github.com/lib/pq" // required
 )
@@ -13,41 +15,93 @@ type Repository interface {
 	// agent queries
 	CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent, error)
 	DeleteAgent(ctx context.Context, id int64) (Agent, error)
-	GetAgent(ctx context.Context, id int64) (Agent, error)
-	ListAgents(ctx context.Context) ([]Agent, error)
-	UpdateAgent(ctx context.Context, arg UpdateAgentParams) (Agent, error)
+	RestoreAgent(ctx context.Context, id int64) (Agent, error)
+	GetAgent(ctx context.Context, arg GetAgentParams) (Agent, error)
+	ListAgents(ctx context.Context, filter AgentFilter, page Page) ([]Agent, error)
+	CountAgents(ctx context.Context, filter AgentFilter) (int64, error)
+	UpdateAgent(ctx context.Context, arg UpdateAgentParams, expectedVersion *int32) (Agent, error)
 	ListAgentsByAuthorIDs(ctx context.Context, authorIDs []int64) ([]ListAgentsByAuthorIDsRow, error)
+	ListAgentsByIDs(ctx context.Context, ids []int64) ([]Agent, error)
 
 	// author queries
 	CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error)
 	DeleteAuthor(ctx context.Context, id int64) (Author, error)
-	GetAuthor(ctx context.Context, id int64) (Author, error)
-	ListAuthors(ctx context.Context) ([]Author, error)
-	UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Author, error)
-	ListAuthorsByAgentIDs(ctx context.Context, agentIDs []int64) ([]Author, error)
-	ListAuthorsByBookIDs(ctx context.Context, bookIDs []int64) ([]ListAuthorsByBookIDsRow, error)
+	RestoreAuthor(ctx context.Context, id int64) (Author, error)
+	GetAuthor(ctx context.Context, arg GetAuthorParams) (Author, error)
+	ListAuthors(ctx context.Context, filter AuthorFilter, page Page) ([]Author, error)
+	CountAuthors(ctx context.Context, filter AuthorFilter) (int64, error)
+	UpdateAuthor(ctx context.Context, arg UpdateAuthorParams, expectedVersion *int32) (Author, error)
+	ListAuthorsByAgentIDsPage(ctx context.Context, arg ListAuthorsByAgentIDsPageParams) ([]ListAuthorsByAgentIDsPageRow, error)
+	CountAuthorsByAgentIDs(ctx context.Context, agentIDs []int64) ([]CountAuthorsByAgentIDsRow, error)
+	ListAuthorsByBookIDsPage(ctx context.Context, arg ListAuthorsByBookIDsPageParams) ([]ListAuthorsByBookIDsPageRow, error)
+	CountAuthorsByBookIDs(ctx context.Context, bookIDs []int64) ([]CountAuthorsByBookIDsRow, error)
+	ListAuthorsByIDs(ctx context.Context, ids []int64) ([]Author, error)
 
 	// book queries
 	CreateBook(ctx context.Context, bookArg CreateBookParams, authorIDs []int64) (*Book, error)
-	UpdateBook(ctx context.Context, bookArg UpdateBookParams, authorIDs []int64) (*Book, error)
+	// UpdateBook also returns the IDs of the authors added to the book.
+	UpdateBook(ctx context.Context, bookArg UpdateBookParams, authorIDs []int64, expectedVersion *int32) (*Book, []int64, error)
 	DeleteBook(ctx context.Context, id int64) (Book, error)
-	GetBook(ctx context.Context, id int64) (Book, error)
-	ListBooks(ctx context.Context) ([]Book, error)
-	ListBooksByAuthorIDs(ctx context.Context, authorIDs []int64) ([]ListBooksByAuthorIDsRow, error)
+	RestoreBook(ctx context.Context, id int64) (Book, error)
+	GetBook(ctx context.Context, arg GetBookParams) (Book, error)
+	ListBooks(ctx context.Context, filter BookFilter, page Page) ([]Book, error)
+	CountBooks(ctx context.Context, filter BookFilter) (int64, error)
+	ListBooksByAuthorIDsPage(ctx context.Context, arg ListBooksByAuthorIDsPageParams) ([]ListBooksByAuthorIDsPageRow, error)
+	CountBooksByAuthorIDs(ctx context.Context, authorIDs []int64) ([]CountBooksByAuthorIDsRow, error)
+	ListBooksByIDs(ctx context.Context, ids []int64) ([]Book, error)
+
+	// outbox queries
+	ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error)
+	MarkOutboxEventDelivered(ctx context.Context, id int64) error
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+
+	// audit queries
+	ListAuditEntries(ctx context.Context, filter AuditFilter, page Page) ([]AuditEntry, error)
+	ListAuditEntriesByEntityIDsPage(ctx context.Context, arg ListAuditEntriesByEntityIDsPageParams) ([]AuditEntry, error)
+
+	// purge queries
+	PurgeDeleted(ctx context.Context, before time.Time) (PurgeResult, error)
+
+	// search queries
+	Search(ctx context.Context, arg SearchParams) ([]SearchRow, error)
 }
 
 type repoSvc struct {
 	*Queries
-	db *sql.DB
+	db          *sql.DB
+	middlewares []DBTXMiddleware
+}
+
+// wrap applies the middlewares of the repository to dbtx.
+func (r *repoSvc) wrap(dbtx DBTX) DBTX {
+	return wrapDBTX(dbtx, r.middlewares)
+}
+
+// withTx runs txFn in a transaction and records the change it makes as an
+// outbox event and an audit entry within the same transaction. The event is
+// recorded even if the server delivers no events, since other servers
+// sharing the database may, it is pending until a sink is configured.
+func (r *repoSvc) withTx(ctx context.Context, txFn func(*Queries) (Change, error)) error {
+	return r.inTx(ctx, func(q *Queries) error {
+		change, err := txFn(q)
+		if err != nil {
+			return err
+		}
+		if err := q.CreateOutboxEvent(ctx, change.OutboxEvent()); err != nil {
+			return err
+		}
+		return q.CreateAuditEntry(ctx, change.AuditEntry())
+	})
 }
 
-func (r *repoSvc) withTx(ctx context.Context, txFn func(*Queries) error) error {
+// inTx runs txFn in a transaction, which is committed unless txFn fails.
+func (r *repoSvc) inTx(ctx context.Context, txFn func(*Queries) error) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	q := New(tx)
-	err = txFn(q)
+	err = txFn(New(r.wrap(tx)))
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			err = fmt.Errorf("tx failed: %v, unable to rollback: %v", err, rbErr)
@@ -58,57 +112,306 @@ func (r *repoSvc) withTx(ctx context.Context, txFn func(*Queries) error) error {
 	return err
 }
 
+// The methods below change the entities and return the changes to withTx,
+// which records them, see NewAgentChange. They also translate the errors of
+// the generated queries which may fail because of missing rows or violated
+// constraints, see translateError.
+//
+// Every change increments the version of the entity, the updates fail with a
+// VersionError unless the entity is at the expected version. Deleting an
+// entity only marks it deleted, it is removed by PurgeDeleted once it has
+// been deleted long enough. Its associations are kept, so that restoring it
+// brings them back.
+
+func (r *repoSvc) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent, error) {
+	var agent Agent
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		var err error
+		if agent, err = q.CreateAgent(ctx, arg); err != nil {
+			return Change{}, err
+		}
+		return NewAgentChange(ctx, OpCreate, nil, &agent), nil
+	})
+	return agent, translateError(err)
+}
+
+func (r *repoSvc) DeleteAgent(ctx context.Context, id int64) (Agent, error) {
+	var agent Agent
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		if _, err := q.GetAgentForUpdate(ctx, id); err != nil {
+			return Change{}, err
+		}
+		// the rows of deleted authors still reference the agent, so the
+		// foreign key only restricts the purge
+		n, err := q.CountLiveAuthorsByAgentID(ctx, id)
+		if err != nil {
+			return Change{}, err
+		}
+		if n > 0 {
+			return Change{}, referencedError("agents", "authors", "authors_agent_id_fkey", id)
+		}
+		if agent, err = q.DeleteAgent(ctx, id); err != nil {
+			return Change{}, err
+		}
+		return NewAgentChange(ctx, OpDelete, &agent, nil), nil
+	})
+	return agent, translateDeleteError(err)
+}
+
+func (r *repoSvc) RestoreAgent(ctx context.Context, id int64) (Agent, error) {
+	var agent Agent
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		var err error
+		if agent, err = q.RestoreAgent(ctx, id); err != nil {
+			return Change{}, err
+		}
+		return NewAgentChange(ctx, OpRestore, nil, &agent), nil
+	})
+	return agent, translateError(err)
+}
+
+func (r *repoSvc) GetAgent(ctx context.Context, arg GetAgentParams) (Agent, error) {
+	agent, err := r.Queries.GetAgent(ctx, arg)
+	return agent, translateError(err)
+}
+
+func (r *repoSvc) UpdateAgent(ctx context.Context, arg UpdateAgentParams, expectedVersion *int32) (Agent, error) {
+	var agent Agent
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		before, err := q.GetAgentForUpdate(ctx, arg.ID)
+		if err != nil {
+			return Change{}, err
+		}
+		if err := checkVersion(EntityAgent, arg.ID, expectedVersion, before.Version); err != nil {
+			return Change{}, err
+		}
+		if agent, err = q.UpdateAgent(ctx, arg); err != nil {
+			return Change{}, err
+		}
+		return NewAgentChange(ctx, OpUpdate, &before, &agent), nil
+	})
+	return agent, translateError(err)
+}
+
+func (r *repoSvc) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error) {
+	var author Author
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		if err := lockAgent(ctx, q, arg.AgentID); err != nil {
+			return Change{}, err
+		}
+		var err error
+		if author, err = q.CreateAuthor(ctx, arg); err != nil {
+			return Change{}, err
+		}
+		return NewAuthorChange(ctx, OpCreate, nil, &author), nil
+	})
+	return author, translateError(err)
+}
+
+func (r *repoSvc) DeleteAuthor(ctx context.Context, id int64) (Author, error) {
+	var author Author
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		var err error
+		if author, err = q.DeleteAuthor(ctx, id); err != nil {
+			return Change{}, err
+		}
+		return NewAuthorChange(ctx, OpDelete, &author, nil), nil
+	})
+	return author, translateDeleteError(err)
+}
+
+func (r *repoSvc) RestoreAuthor(ctx context.Context, id int64) (Author, error) {
+	var author Author
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		var err error
+		if author, err = q.RestoreAuthor(ctx, id); err != nil {
+			return Change{}, err
+		}
+		// the agent may have been deleted after the author
+		if err := lockAgent(ctx, q, author.AgentID); err != nil {
+			return Change{}, err
+		}
+		return NewAuthorChange(ctx, OpRestore, nil, &author), nil
+	})
+	return author, translateError(err)
+}
+
+func (r *repoSvc) GetAuthor(ctx context.Context, arg GetAuthorParams) (Author, error) {
+	author, err := r.Queries.GetAuthor(ctx, arg)
+	return author, translateError(err)
+}
+
+func (r *repoSvc) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams, expectedVersion *int32) (Author, error) {
+	var author Author
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		before, err := q.GetAuthorForUpdate(ctx, arg.ID)
+		if err != nil {
+			return Change{}, err
+		}
+		if err := checkVersion(EntityAuthor, arg.ID, expectedVersion, before.Version); err != nil {
+			return Change{}, err
+		}
+		if err := lockAgent(ctx, q, arg.AgentID); err != nil {
+			return Change{}, err
+		}
+		if author, err = q.UpdateAuthor(ctx, arg); err != nil {
+			return Change{}, err
+		}
+		return NewAuthorChange(ctx, OpUpdate, &before, &author), nil
+	})
+	return author, translateError(err)
+}
+
 func (r *repoSvc) CreateBook(ctx context.Context, bookArg CreateBookParams, authorIDs []int64) (*Book, error) {
 	book := new(Book)
-	err := r.withTx(ctx, func(q *Queries) error {
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		if err := lockAuthors(ctx, q, authorIDs); err != nil {
+			return Change{}, err
+		}
 		res, err := q.CreateBook(ctx, bookArg)
 		if err != nil {
-			return err
+			return Change{}, err
 		}
 		for _, authorID := range authorIDs {
 			if err := q.SetBookAuthor(ctx, SetBookAuthorParams{
 				BookID:   res.ID,
 				AuthorID: authorID,
 			}); err != nil {
-				return err
+				return Change{}, err
 			}
 		}
 		book = &res
-		return nil
+		return NewBookChange(ctx, OpCreate, nil, &BookState{res, authorIDs}), nil
 	})
-	return book, err
+	return book, translateError(err)
 }
 
-func (r *repoSvc) UpdateBook(ctx context.Context, bookArg UpdateBookParams, authorIDs []int64) (*Book, error) {
+func (r *repoSvc) UpdateBook(ctx context.Context, bookArg UpdateBookParams, authorIDs []int64, expectedVersion *int32) (*Book, []int64, error) {
 	book := new(Book)
-	err := r.withTx(ctx, func(q *Queries) error {
+	var added []int64
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		before, err := bookStateForUpdate(ctx, q, bookArg.ID)
+		if err != nil {
+			return Change{}, err
+		}
+		if err := checkVersion(EntityBook, bookArg.ID, expectedVersion, before.Version); err != nil {
+			return Change{}, err
+		}
+		if err := lockAuthors(ctx, q, authorIDs); err != nil {
+			return Change{}, err
+		}
 		res, err := q.UpdateBook(ctx, bookArg)
 		if err != nil {
-			return err
+			return Change{}, err
 		}
 		if err = q.UnsetBookAuthors(ctx, res.ID); err != nil {
-			return err
+			return Change{}, err
 		}
 		for _, authorID := range authorIDs {
 			if err := q.SetBookAuthor(ctx, SetBookAuthorParams{
 				BookID:   res.ID,
 				AuthorID: authorID,
 			}); err != nil {
-				return err
+				return Change{}, err
 			}
 		}
 		book = &res
-		return nil
+		after := &BookState{res, authorIDs}
+		added = AddedAuthorIDs(before, after)
+		return NewBookChange(ctx, OpUpdate, before, after), nil
+	})
+	return book, added, translateError(err)
+}
+
+func (r *repoSvc) DeleteBook(ctx context.Context, id int64) (Book, error) {
+	var book Book
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		before, err := bookStateForUpdate(ctx, q, id)
+		if err != nil {
+			return Change{}, err
+		}
+		if book, err = q.DeleteBook(ctx, id); err != nil {
+			return Change{}, err
+		}
+		return NewBookChange(ctx, OpDelete, before, nil), nil
 	})
-	return book, err
+	return book, translateDeleteError(err)
+}
+
+func (r *repoSvc) RestoreBook(ctx context.Context, id int64) (Book, error) {
+	var book Book
+	err := r.withTx(ctx, func(q *Queries) (Change, error) {
+		var err error
+		if book, err = q.RestoreBook(ctx, id); err != nil {
+			return Change{}, err
+		}
+		authorIDs, err := q.ListAuthorIDsByBookID(ctx, id)
+		if err != nil {
+			return Change{}, err
+		}
+		return NewBookChange(ctx, OpRestore, nil, &BookState{book, authorIDs}), nil
+	})
+	return book, translateError(err)
+}
+
+func (r *repoSvc) GetBook(ctx context.Context, arg GetBookParams) (Book, error) {
+	book, err := r.Queries.GetBook(ctx, arg)
+	return book, translateError(err)
+}
+
+// lockAgent locks the agent referenced by an author against deletion.
+// Deleted agents are invalid references, although their rows still exist.
+func lockAgent(ctx context.Context, q *Queries, agentID int64) error {
+	_, err := q.LockAgentForShare(ctx, agentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return referenceError("authors", "authors_agent_id_fkey",
+			fmt.Sprintf("Key (agent_id)=(%d) is not present in table \"agents\".", agentID))
+	}
+	return err
+}
+
+// lockAuthors locks the authors of a book against deletion, see lockAgent.
+func lockAuthors(ctx context.Context, q *Queries, authorIDs []int64) error {
+	ids, err := q.LockAuthorsForShare(ctx, authorIDs)
+	if err != nil {
+		return err
+	}
+	live := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		live[id] = true
+	}
+	for _, authorID := range authorIDs {
+		if !live[authorID] {
+			return referenceError("book_authors", "book_authors_author_id_fkey",
+				fmt.Sprintf("Key (author_id)=(%d) is not present in table \"authors\".", authorID))
+		}
+	}
+	return nil
+}
+
+// bookStateForUpdate locks the book and returns it with its authors which
+// are not deleted.
+func bookStateForUpdate(ctx context.Context, q *Queries, id int64) (*BookState, error) {
+	book, err := q.GetBookForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	authorIDs, err := q.ListAuthorIDsByBookID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &BookState{book, authorIDs}, nil
 }
 
-// NewRepository returns an implementation of the Repository interface.
-func NewRepository(db *sql.DB) Repository {
-	return &repoSvc{
-		Queries: New(db),
-		db:      db,
+// NewRepository returns an implementation of the Repository interface. The
+// middlewares are applied to the database and to every transaction.
+func NewRepository(db *sql.DB, middlewares ...DBTXMiddleware) Repository {
+	r := &repoSvc{
+		db:          db,
+		middlewares: middlewares,
 	}
+	r.Queries = New(r.wrap(db))
+	return r
 }
 
 // Open opens a database specified by the data source name.
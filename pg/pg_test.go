@@ -0,0 +1,39 @@
+package pg_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"       // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/repotest" // update the username
+)
+
+// TestRepository runs the conformance suite against the database given by
+// the GQLGEN_SQLC_TEST_DSN environment variable. The database is migrated
+// and emptied by every test, do not point it at a database worth keeping.
+func TestRepository(t *testing.T) {
+	dsn := os.Getenv("GQLGEN_SQLC_TEST_DSN")
+	if dsn == "" {
+		t.Skip("GQLGEN_SQLC_TEST_DSN is not set")
+	}
+	db, err := pg.Open(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	migrator, err := pg.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	repotest.Run(t, func(t *testing.T) pg.Repository {
+		_, err := db.Exec(`TRUNCATE agents, authors, books, book_authors, outbox_events, audit_entries RESTART IDENTITY`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pg.NewRepository(db)
+	})
+}
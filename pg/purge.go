@@ -0,0 +1,37 @@
+package pg
+
+import (
+	"context"
+	"time"
+)
+
+// PurgeResult is the number of rows removed by PurgeDeleted.
+type PurgeResult struct {
+	Agents  int64
+	Authors int64
+	Books   int64
+}
+
+// PurgeDeleted removes the entities deleted before the given time, together
+// with their associations. Agents still referenced by authors, which have
+// not been purged yet, are kept until their authors are gone. Purged
+// entities cannot be restored and their removal is not recorded as a change.
+func (r *repoSvc) PurgeDeleted(ctx context.Context, before time.Time) (PurgeResult, error) {
+	var res PurgeResult
+	err := r.inTx(ctx, func(q *Queries) error {
+		var err error
+		// the book_authors rows cascade
+		if res.Books, err = q.PurgeBooks(ctx, before); err != nil {
+			return err
+		}
+		if res.Authors, err = q.PurgeAuthors(ctx, before); err != nil {
+			return err
+		}
+		res.Agents, err = q.PurgeAgents(ctx, before)
+		return err
+	})
+	if err != nil {
+		return PurgeResult{}, err
+	}
+	return res, nil
+}
@@ -6,14 +6,187 @@ package pg
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/lib/pq"
 )
 
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+UPDATE outbox_events
+SET next_attempt_at = now() + $1::bigint * interval '1 millisecond'
+WHERE id IN (
+    SELECT id FROM outbox_events
+    WHERE delivered_at IS NULL AND next_attempt_at <= now()
+    ORDER BY id
+    LIMIT $2::int
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, entity, entity_id, op, before, after, actor, created_at, attempts,
+    next_attempt_at, last_error, delivered_at
+`
+
+type ClaimOutboxEventsParams struct {
+	LeaseMs   int64
+	BatchSize int32
+}
+
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error) {
+	rows, err := q.db.QueryContext(ctx, claimOutboxEvents, arg.LeaseMs, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OutboxEvent
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Entity,
+			&i.EntityID,
+			&i.Op,
+			&i.Before,
+			&i.After,
+			&i.Actor,
+			&i.CreatedAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countAuthorsByAgentIDs = `-- name: CountAuthorsByAgentIDs :many
+SELECT agent_id, COUNT(*) AS count FROM authors
+WHERE agent_id = ANY($1::bigint[]) AND deleted_at IS NULL
+GROUP BY agent_id
+`
+
+type CountAuthorsByAgentIDsRow struct {
+	AgentID int64
+	Count   int64
+}
+
+func (q *Queries) CountAuthorsByAgentIDs(ctx context.Context, dollar_1 []int64) ([]CountAuthorsByAgentIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, countAuthorsByAgentIDs, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountAuthorsByAgentIDsRow
+	for rows.Next() {
+		var i CountAuthorsByAgentIDsRow
+		if err := rows.Scan(&i.AgentID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countAuthorsByBookIDs = `-- name: CountAuthorsByBookIDs :many
+SELECT book_authors.book_id, COUNT(*) AS count FROM book_authors, authors
+WHERE book_authors.author_id = authors.id AND book_authors.book_id = ANY($1::bigint[])
+AND authors.deleted_at IS NULL
+GROUP BY book_authors.book_id
+`
+
+type CountAuthorsByBookIDsRow struct {
+	BookID int64
+	Count  int64
+}
+
+func (q *Queries) CountAuthorsByBookIDs(ctx context.Context, dollar_1 []int64) ([]CountAuthorsByBookIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, countAuthorsByBookIDs, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountAuthorsByBookIDsRow
+	for rows.Next() {
+		var i CountAuthorsByBookIDsRow
+		if err := rows.Scan(&i.BookID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countBooksByAuthorIDs = `-- name: CountBooksByAuthorIDs :many
+SELECT book_authors.author_id, COUNT(*) AS count FROM book_authors, books
+WHERE book_authors.book_id = books.id AND book_authors.author_id = ANY($1::bigint[])
+AND books.deleted_at IS NULL
+GROUP BY book_authors.author_id
+`
+
+type CountBooksByAuthorIDsRow struct {
+	AuthorID int64
+	Count    int64
+}
+
+func (q *Queries) CountBooksByAuthorIDs(ctx context.Context, dollar_1 []int64) ([]CountBooksByAuthorIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, countBooksByAuthorIDs, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountBooksByAuthorIDsRow
+	for rows.Next() {
+		var i CountBooksByAuthorIDsRow
+		if err := rows.Scan(&i.AuthorID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countLiveAuthorsByAgentID = `-- name: CountLiveAuthorsByAgentID :one
+SELECT COUNT(*) FROM authors
+WHERE agent_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) CountLiveAuthorsByAgentID(ctx context.Context, agentID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLiveAuthorsByAgentID, agentID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAgent = `-- name: CreateAgent :one
 INSERT INTO agents (name, email)
 VALUES ($1, $2)
-RETURNING id, name, email
+RETURNING id, name, email, deleted_at, version
 `
 
 type CreateAgentParams struct {
@@ -24,14 +197,44 @@ type CreateAgentParams struct {
 func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent, error) {
 	row := q.db.QueryRowContext(ctx, createAgent, arg.Name, arg.Email)
 	var i Agent
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeletedAt,
+		&i.Version,
+	)
 	return i, err
 }
 
+const createAuditEntry = `-- name: CreateAuditEntry :exec
+INSERT INTO audit_entries (entity, entity_id, op, actor, changes)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateAuditEntryParams struct {
+	Entity   string
+	EntityID int64
+	Op       string
+	Actor    sql.NullString
+	Changes  json.RawMessage
+}
+
+func (q *Queries) CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditEntry,
+		arg.Entity,
+		arg.EntityID,
+		arg.Op,
+		arg.Actor,
+		arg.Changes,
+	)
+	return err
+}
+
 const createAuthor = `-- name: CreateAuthor :one
 INSERT INTO authors (name, website, agent_id)
 VALUES ($1, $2, $3)
-RETURNING id, name, website, agent_id
+RETURNING id, name, website, agent_id, deleted_at, version
 `
 
 type CreateAuthorParams struct {
@@ -48,6 +251,8 @@ func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Aut
 		&i.Name,
 		&i.Website,
 		&i.AgentID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -55,7 +260,7 @@ func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Aut
 const createBook = `-- name: CreateBook :one
 INSERT INTO books (title, description, cover)
 VALUES ($1, $2, $3)
-RETURNING id, title, description, cover
+RETURNING id, title, description, cover, deleted_at, version
 `
 
 type CreateBookParams struct {
@@ -72,27 +277,79 @@ func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, e
 		&i.Title,
 		&i.Description,
 		&i.Cover,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (entity, entity_id, op, before, after, actor)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateOutboxEventParams struct {
+	Entity   string
+	EntityID int64
+	Op       string
+	Before   json.RawMessage
+	After    json.RawMessage
+	Actor    sql.NullString
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.ExecContext(ctx, createOutboxEvent,
+		arg.Entity,
+		arg.EntityID,
+		arg.Op,
+		arg.Before,
+		arg.After,
+		arg.Actor,
+	)
+	return err
+}
+
+const createPersistedQuery = `-- name: CreatePersistedQuery :exec
+INSERT INTO persisted_queries (hash, query)
+VALUES ($1, $2)
+ON CONFLICT (hash) DO NOTHING
+`
+
+type CreatePersistedQueryParams struct {
+	Hash  string
+	Query string
+}
+
+func (q *Queries) CreatePersistedQuery(ctx context.Context, arg CreatePersistedQueryParams) error {
+	_, err := q.db.ExecContext(ctx, createPersistedQuery, arg.Hash, arg.Query)
+	return err
+}
+
 const deleteAgent = `-- name: DeleteAgent :one
-DELETE FROM agents
-WHERE id = $1
-RETURNING id, name, email
+UPDATE agents
+SET deleted_at = now(), version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, email, deleted_at, version
 `
 
 func (q *Queries) DeleteAgent(ctx context.Context, id int64) (Agent, error) {
 	row := q.db.QueryRowContext(ctx, deleteAgent, id)
 	var i Agent
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeletedAt,
+		&i.Version,
+	)
 	return i, err
 }
 
 const deleteAuthor = `-- name: DeleteAuthor :one
-DELETE FROM authors
-WHERE id = $1
-RETURNING id, name, website, agent_id
+UPDATE authors
+SET deleted_at = now(), version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, website, agent_id, deleted_at, version
 `
 
 func (q *Queries) DeleteAuthor(ctx context.Context, id int64) (Author, error) {
@@ -103,14 +360,17 @@ func (q *Queries) DeleteAuthor(ctx context.Context, id int64) (Author, error) {
 		&i.Name,
 		&i.Website,
 		&i.AgentID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const deleteBook = `-- name: DeleteBook :one
-DELETE FROM books
-WHERE id = $1
-RETURNING id, title, description, cover
+UPDATE books
+SET deleted_at = now(), version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, description, cover, deleted_at, version
 `
 
 func (q *Queries) DeleteBook(ctx context.Context, id int64) (Book, error) {
@@ -121,71 +381,214 @@ func (q *Queries) DeleteBook(ctx context.Context, id int64) (Book, error) {
 		&i.Title,
 		&i.Description,
 		&i.Cover,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getAgent = `-- name: GetAgent :one
-SELECT id, name, email FROM agents
-WHERE id = $1
+SELECT id, name, email, deleted_at, version FROM agents
+WHERE id = $1 AND (deleted_at IS NULL OR $2::bool)
+`
+
+type GetAgentParams struct {
+	ID             int64
+	IncludeDeleted bool
+}
+
+func (q *Queries) GetAgent(ctx context.Context, arg GetAgentParams) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, getAgent, arg.ID, arg.IncludeDeleted)
+	var i Agent
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getAgentForUpdate = `-- name: GetAgentForUpdate :one
+SELECT id, name, email, deleted_at, version FROM agents
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE
 `
 
-func (q *Queries) GetAgent(ctx context.Context, id int64) (Agent, error) {
-	row := q.db.QueryRowContext(ctx, getAgent, id)
+func (q *Queries) GetAgentForUpdate(ctx context.Context, id int64) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, getAgentForUpdate, id)
 	var i Agent
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeletedAt,
+		&i.Version,
+	)
 	return i, err
 }
 
 const getAuthor = `-- name: GetAuthor :one
-SELECT id, name, website, agent_id FROM authors
-WHERE id = $1
+SELECT id, name, website, agent_id, deleted_at, version FROM authors
+WHERE id = $1 AND (deleted_at IS NULL OR $2::bool)
 `
 
-func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
-	row := q.db.QueryRowContext(ctx, getAuthor, id)
+type GetAuthorParams struct {
+	ID             int64
+	IncludeDeleted bool
+}
+
+func (q *Queries) GetAuthor(ctx context.Context, arg GetAuthorParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, arg.ID, arg.IncludeDeleted)
 	var i Author
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Website,
 		&i.AgentID,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getAuthorForUpdate = `-- name: GetAuthorForUpdate :one
+SELECT id, name, website, agent_id, deleted_at, version FROM authors
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE
+`
+
+func (q *Queries) GetAuthorForUpdate(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthorForUpdate, id)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.AgentID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getBook = `-- name: GetBook :one
-SELECT id, title, description, cover FROM books
-WHERE id = $1
+SELECT id, title, description, cover, deleted_at, version FROM books
+WHERE id = $1 AND (deleted_at IS NULL OR $2::bool)
+`
+
+type GetBookParams struct {
+	ID             int64
+	IncludeDeleted bool
+}
+
+func (q *Queries) GetBook(ctx context.Context, arg GetBookParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, getBook, arg.ID, arg.IncludeDeleted)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Cover,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getBookForUpdate = `-- name: GetBookForUpdate :one
+SELECT id, title, description, cover, deleted_at, version FROM books
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE
 `
 
-func (q *Queries) GetBook(ctx context.Context, id int64) (Book, error) {
-	row := q.db.QueryRowContext(ctx, getBook, id)
+func (q *Queries) GetBookForUpdate(ctx context.Context, id int64) (Book, error) {
+	row := q.db.QueryRowContext(ctx, getBookForUpdate, id)
 	var i Book
 	err := row.Scan(
 		&i.ID,
 		&i.Title,
 		&i.Description,
 		&i.Cover,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
-const listAgents = `-- name: ListAgents :many
-SELECT id, name, email FROM agents
-ORDER BY name
+const getImportedPersistedQuery = `-- name: GetImportedPersistedQuery :one
+SELECT query FROM persisted_queries
+WHERE hash = $1 AND imported
+`
+
+func (q *Queries) GetImportedPersistedQuery(ctx context.Context, hash string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getImportedPersistedQuery, hash)
+	var query string
+	err := row.Scan(&query)
+	return query, err
+}
+
+const getPersistedQuery = `-- name: GetPersistedQuery :one
+SELECT query FROM persisted_queries
+WHERE hash = $1
+`
+
+func (q *Queries) GetPersistedQuery(ctx context.Context, hash string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPersistedQuery, hash)
+	var query string
+	err := row.Scan(&query)
+	return query, err
+}
+
+const importPersistedQuery = `-- name: ImportPersistedQuery :exec
+INSERT INTO persisted_queries (hash, query, imported)
+VALUES ($1, $2, true)
+ON CONFLICT (hash) DO UPDATE SET imported = true
+`
+
+type ImportPersistedQueryParams struct {
+	Hash  string
+	Query string
+}
+
+func (q *Queries) ImportPersistedQuery(ctx context.Context, arg ImportPersistedQueryParams) error {
+	_, err := q.db.ExecContext(ctx, importPersistedQuery, arg.Hash, arg.Query)
+	return err
+}
+
+const listAgentsByAuthorIDs = `-- name: ListAgentsByAuthorIDs :many
+SELECT agents.id, agents.name, agents.email, agents.deleted_at, agents.version,
+    authors.id AS author_id FROM agents, authors
+WHERE agents.id = authors.agent_id AND authors.id = ANY($1::bigint[])
 `
 
-func (q *Queries) ListAgents(ctx context.Context) ([]Agent, error) {
-	rows, err := q.db.QueryContext(ctx, listAgents)
+type ListAgentsByAuthorIDsRow struct {
+	ID        int64
+	Name      string
+	Email     string
+	DeletedAt sql.NullTime
+	Version   int32
+	AuthorID  int64
+}
+
+func (q *Queries) ListAgentsByAuthorIDs(ctx context.Context, dollar_1 []int64) ([]ListAgentsByAuthorIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAgentsByAuthorIDs, pq.Array(dollar_1))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Agent
+	var items []ListAgentsByAuthorIDsRow
 	for rows.Next() {
-		var i Agent
-		if err := rows.Scan(&i.ID, &i.Name, &i.Email); err != nil {
+		var i ListAgentsByAuthorIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.DeletedAt,
+			&i.Version,
+			&i.AuthorID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -199,32 +602,26 @@ func (q *Queries) ListAgents(ctx context.Context) ([]Agent, error) {
 	return items, nil
 }
 
-const listAgentsByAuthorIDs = `-- name: ListAgentsByAuthorIDs :many
-SELECT agents.id, agents.name, agents.email, authors.id AS author_id FROM agents, authors
-WHERE agents.id = authors.agent_id AND authors.id  = ANY($1::bigint[])
+const listAgentsByIDs = `-- name: ListAgentsByIDs :many
+SELECT id, name, email, deleted_at, version FROM agents
+WHERE id = ANY($1::bigint[])
 `
 
-type ListAgentsByAuthorIDsRow struct {
-	ID       int64
-	Name     string
-	Email    string
-	AuthorID int64
-}
-
-func (q *Queries) ListAgentsByAuthorIDs(ctx context.Context, dollar_1 []int64) ([]ListAgentsByAuthorIDsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listAgentsByAuthorIDs, pq.Array(dollar_1))
+func (q *Queries) ListAgentsByIDs(ctx context.Context, dollar_1 []int64) ([]Agent, error) {
+	rows, err := q.db.QueryContext(ctx, listAgentsByIDs, pq.Array(dollar_1))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListAgentsByAuthorIDsRow
+	var items []Agent
 	for rows.Next() {
-		var i ListAgentsByAuthorIDsRow
+		var i Agent
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
 			&i.Email,
-			&i.AuthorID,
+			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -239,25 +636,52 @@ func (q *Queries) ListAgentsByAuthorIDs(ctx context.Context, dollar_1 []int64) (
 	return items, nil
 }
 
-const listAuthors = `-- name: ListAuthors :many
-SELECT id, name, website, agent_id FROM authors
-ORDER BY name
+const listAuditEntriesByEntityIDsPage = `-- name: ListAuditEntriesByEntityIDsPage :many
+SELECT id, entity, entity_id, op, actor, changed_at, changes FROM (
+    SELECT audit_entries.id, audit_entries.entity, audit_entries.entity_id, audit_entries.op,
+        audit_entries.actor, audit_entries.changed_at, audit_entries.changes, ROW_NUMBER() OVER (
+        PARTITION BY audit_entries.entity_id
+        ORDER BY audit_entries.id DESC
+    ) AS row_number
+    FROM audit_entries
+    WHERE audit_entries.entity = $1 AND audit_entries.entity_id = ANY($2::bigint[])
+    AND (NOT $3::bool OR audit_entries.id < $4::bigint)
+) AS page
+WHERE page.row_number <= $5::int
+ORDER BY page.entity_id, page.row_number
 `
 
-func (q *Queries) ListAuthors(ctx context.Context) ([]Author, error) {
-	rows, err := q.db.QueryContext(ctx, listAuthors)
+type ListAuditEntriesByEntityIDsPageParams struct {
+	Entity    string
+	EntityIds []int64
+	HasAfter  bool
+	AfterID   int64
+	RowLimit  int32
+}
+
+func (q *Queries) ListAuditEntriesByEntityIDsPage(ctx context.Context, arg ListAuditEntriesByEntityIDsPageParams) ([]AuditEntry, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEntriesByEntityIDsPage,
+		arg.Entity,
+		pq.Array(arg.EntityIds),
+		arg.HasAfter,
+		arg.AfterID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Author
+	var items []AuditEntry
 	for rows.Next() {
-		var i Author
+		var i AuditEntry
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.Website,
-			&i.AgentID,
+			&i.Entity,
+			&i.EntityID,
+			&i.Op,
+			&i.Actor,
+			&i.ChangedAt,
+			&i.Changes,
 		); err != nil {
 			return nil, err
 		}
@@ -272,25 +696,99 @@ func (q *Queries) ListAuthors(ctx context.Context) ([]Author, error) {
 	return items, nil
 }
 
-const listAuthorsByAgentIDs = `-- name: ListAuthorsByAgentIDs :many
-SELECT authors.id, authors.name, authors.website, authors.agent_id FROM authors, agents
-WHERE authors.agent_id = agents.id AND agents.id = ANY($1::bigint[])
+const listAuthorIDsByBookID = `-- name: ListAuthorIDsByBookID :many
+SELECT book_authors.author_id FROM book_authors, authors
+WHERE book_authors.author_id = authors.id AND book_authors.book_id = $1
+AND authors.deleted_at IS NULL
+ORDER BY book_authors.author_id
 `
 
-func (q *Queries) ListAuthorsByAgentIDs(ctx context.Context, dollar_1 []int64) ([]Author, error) {
-	rows, err := q.db.QueryContext(ctx, listAuthorsByAgentIDs, pq.Array(dollar_1))
+func (q *Queries) ListAuthorIDsByBookID(ctx context.Context, bookID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorIDsByBookID, bookID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Author
+	var items []int64
 	for rows.Next() {
-		var i Author
+		var author_id int64
+		if err := rows.Scan(&author_id); err != nil {
+			return nil, err
+		}
+		items = append(items, author_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByAgentIDsPage = `-- name: ListAuthorsByAgentIDsPage :many
+SELECT id, name, website, agent_id, version FROM (
+    SELECT authors.id, authors.name, authors.website, authors.agent_id, authors.deleted_at, authors.version, ROW_NUMBER() OVER (
+        PARTITION BY authors.agent_id
+        ORDER BY
+            CASE WHEN $1::bool THEN authors.name END DESC,
+            CASE WHEN $1::bool THEN authors.id END DESC,
+            authors.name, authors.id
+    ) AS row_number
+    FROM authors
+    WHERE authors.agent_id = ANY($2::bigint[]) AND authors.deleted_at IS NULL
+    AND (NOT $3::bool OR (authors.name, authors.id) > ($4::text, $5::bigint))
+    AND (NOT $6::bool OR (authors.name, authors.id) < ($7::text, $8::bigint))
+) AS page
+WHERE page.row_number <= $9::int
+ORDER BY page.agent_id, page.row_number
+`
+
+type ListAuthorsByAgentIDsPageParams struct {
+	Reverse   bool
+	AgentIds  []int64
+	HasAfter  bool
+	AfterKey  string
+	AfterID   int64
+	HasBefore bool
+	BeforeKey string
+	BeforeID  int64
+	RowLimit  int32
+}
+
+type ListAuthorsByAgentIDsPageRow struct {
+	ID      int64
+	Name    string
+	Website sql.NullString
+	AgentID int64
+	Version int32
+}
+
+func (q *Queries) ListAuthorsByAgentIDsPage(ctx context.Context, arg ListAuthorsByAgentIDsPageParams) ([]ListAuthorsByAgentIDsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByAgentIDsPage,
+		arg.Reverse,
+		pq.Array(arg.AgentIds),
+		arg.HasAfter,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.HasBefore,
+		arg.BeforeKey,
+		arg.BeforeID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsByAgentIDsPageRow
+	for rows.Next() {
+		var i ListAuthorsByAgentIDsPageRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
 			&i.Website,
 			&i.AgentID,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -305,33 +803,71 @@ func (q *Queries) ListAuthorsByAgentIDs(ctx context.Context, dollar_1 []int64) (
 	return items, nil
 }
 
-const listAuthorsByBookIDs = `-- name: ListAuthorsByBookIDs :many
-SELECT authors.id, authors.name, authors.website, authors.agent_id, book_authors.book_id FROM authors, book_authors
-WHERE book_authors.author_id = authors.id AND book_authors.book_id = ANY($1::bigint[])
+const listAuthorsByBookIDsPage = `-- name: ListAuthorsByBookIDsPage :many
+SELECT id, name, website, agent_id, version, book_id FROM (
+    SELECT authors.id, authors.name, authors.website, authors.agent_id, authors.deleted_at, authors.version, book_authors.book_id, ROW_NUMBER() OVER (
+        PARTITION BY book_authors.book_id
+        ORDER BY
+            CASE WHEN $1::bool THEN authors.name END DESC,
+            CASE WHEN $1::bool THEN authors.id END DESC,
+            authors.name, authors.id
+    ) AS row_number
+    FROM authors, book_authors
+    WHERE book_authors.author_id = authors.id AND book_authors.book_id = ANY($2::bigint[])
+    AND authors.deleted_at IS NULL
+    AND (NOT $3::bool OR (authors.name, authors.id) > ($4::text, $5::bigint))
+    AND (NOT $6::bool OR (authors.name, authors.id) < ($7::text, $8::bigint))
+) AS page
+WHERE page.row_number <= $9::int
+ORDER BY page.book_id, page.row_number
 `
 
-type ListAuthorsByBookIDsRow struct {
+type ListAuthorsByBookIDsPageParams struct {
+	Reverse   bool
+	BookIds   []int64
+	HasAfter  bool
+	AfterKey  string
+	AfterID   int64
+	HasBefore bool
+	BeforeKey string
+	BeforeID  int64
+	RowLimit  int32
+}
+
+type ListAuthorsByBookIDsPageRow struct {
 	ID      int64
 	Name    string
 	Website sql.NullString
 	AgentID int64
+	Version int32
 	BookID  int64
 }
 
-func (q *Queries) ListAuthorsByBookIDs(ctx context.Context, dollar_1 []int64) ([]ListAuthorsByBookIDsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listAuthorsByBookIDs, pq.Array(dollar_1))
+func (q *Queries) ListAuthorsByBookIDsPage(ctx context.Context, arg ListAuthorsByBookIDsPageParams) ([]ListAuthorsByBookIDsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByBookIDsPage,
+		arg.Reverse,
+		pq.Array(arg.BookIds),
+		arg.HasAfter,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.HasBefore,
+		arg.BeforeKey,
+		arg.BeforeID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListAuthorsByBookIDsRow
+	var items []ListAuthorsByBookIDsPageRow
 	for rows.Next() {
-		var i ListAuthorsByBookIDsRow
+		var i ListAuthorsByBookIDsPageRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
 			&i.Website,
 			&i.AgentID,
+			&i.Version,
 			&i.BookID,
 		); err != nil {
 			return nil, err
@@ -347,25 +883,27 @@ func (q *Queries) ListAuthorsByBookIDs(ctx context.Context, dollar_1 []int64) ([
 	return items, nil
 }
 
-const listBooks = `-- name: ListBooks :many
-SELECT id, title, description, cover FROM books
-ORDER BY title
+const listAuthorsByIDs = `-- name: ListAuthorsByIDs :many
+SELECT id, name, website, agent_id, deleted_at, version FROM authors
+WHERE id = ANY($1::bigint[])
 `
 
-func (q *Queries) ListBooks(ctx context.Context) ([]Book, error) {
-	rows, err := q.db.QueryContext(ctx, listBooks)
+func (q *Queries) ListAuthorsByIDs(ctx context.Context, dollar_1 []int64) ([]Author, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByIDs, pq.Array(dollar_1))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Book
+	var items []Author
 	for rows.Next() {
-		var i Book
+		var i Author
 		if err := rows.Scan(
 			&i.ID,
-			&i.Title,
-			&i.Description,
-			&i.Cover,
+			&i.Name,
+			&i.Website,
+			&i.AgentID,
+			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -380,33 +918,71 @@ func (q *Queries) ListBooks(ctx context.Context) ([]Book, error) {
 	return items, nil
 }
 
-const listBooksByAuthorIDs = `-- name: ListBooksByAuthorIDs :many
-SELECT books.id, books.title, books.description, books.cover, book_authors.author_id FROM books, book_authors
-WHERE book_authors.book_id = books.id AND book_authors.author_id = ANY($1::bigint[])
+const listBooksByAuthorIDsPage = `-- name: ListBooksByAuthorIDsPage :many
+SELECT id, title, description, cover, version, author_id FROM (
+    SELECT books.id, books.title, books.description, books.cover, books.deleted_at, books.version, book_authors.author_id, ROW_NUMBER() OVER (
+        PARTITION BY book_authors.author_id
+        ORDER BY
+            CASE WHEN $1::bool THEN books.title END DESC,
+            CASE WHEN $1::bool THEN books.id END DESC,
+            books.title, books.id
+    ) AS row_number
+    FROM books, book_authors
+    WHERE book_authors.book_id = books.id AND book_authors.author_id = ANY($2::bigint[])
+    AND books.deleted_at IS NULL
+    AND (NOT $3::bool OR (books.title, books.id) > ($4::text, $5::bigint))
+    AND (NOT $6::bool OR (books.title, books.id) < ($7::text, $8::bigint))
+) AS page
+WHERE page.row_number <= $9::int
+ORDER BY page.author_id, page.row_number
 `
 
-type ListBooksByAuthorIDsRow struct {
+type ListBooksByAuthorIDsPageParams struct {
+	Reverse   bool
+	AuthorIds []int64
+	HasAfter  bool
+	AfterKey  string
+	AfterID   int64
+	HasBefore bool
+	BeforeKey string
+	BeforeID  int64
+	RowLimit  int32
+}
+
+type ListBooksByAuthorIDsPageRow struct {
 	ID          int64
 	Title       string
 	Description string
 	Cover       string
+	Version     int32
 	AuthorID    int64
 }
 
-func (q *Queries) ListBooksByAuthorIDs(ctx context.Context, dollar_1 []int64) ([]ListBooksByAuthorIDsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listBooksByAuthorIDs, pq.Array(dollar_1))
+func (q *Queries) ListBooksByAuthorIDsPage(ctx context.Context, arg ListBooksByAuthorIDsPageParams) ([]ListBooksByAuthorIDsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksByAuthorIDsPage,
+		arg.Reverse,
+		pq.Array(arg.AuthorIds),
+		arg.HasAfter,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.HasBefore,
+		arg.BeforeKey,
+		arg.BeforeID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListBooksByAuthorIDsRow
+	var items []ListBooksByAuthorIDsPageRow
 	for rows.Next() {
-		var i ListBooksByAuthorIDsRow
+		var i ListBooksByAuthorIDsPageRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Title,
 			&i.Description,
 			&i.Cover,
+			&i.Version,
 			&i.AuthorID,
 		); err != nil {
 			return nil, err
@@ -422,6 +998,341 @@ func (q *Queries) ListBooksByAuthorIDs(ctx context.Context, dollar_1 []int64) ([
 	return items, nil
 }
 
+const listBooksByIDs = `-- name: ListBooksByIDs :many
+SELECT id, title, description, cover, deleted_at, version FROM books
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) ListBooksByIDs(ctx context.Context, dollar_1 []int64) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksByIDs, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAgentForShare = `-- name: LockAgentForShare :one
+SELECT id, name, email, deleted_at, version FROM agents
+WHERE id = $1 AND deleted_at IS NULL
+FOR SHARE
+`
+
+func (q *Queries) LockAgentForShare(ctx context.Context, id int64) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, lockAgentForShare, id)
+	var i Agent
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const lockAuthorsForShare = `-- name: LockAuthorsForShare :many
+SELECT id FROM authors
+WHERE id = ANY($1::bigint[]) AND deleted_at IS NULL
+FOR SHARE
+`
+
+func (q *Queries) LockAuthorsForShare(ctx context.Context, dollar_1 []int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, lockAuthorsForShare, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventDelivered = `-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox_events
+SET delivered_at = now(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventDelivered(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEventDelivered, id)
+	return err
+}
+
+const markOutboxEventFailed = `-- name: MarkOutboxEventFailed :exec
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = $1,
+    next_attempt_at = now() + $2::bigint * interval '1 millisecond'
+WHERE id = $3
+`
+
+type MarkOutboxEventFailedParams struct {
+	LastError sql.NullString
+	BackoffMs int64
+	ID        int64
+}
+
+func (q *Queries) MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEventFailed, arg.LastError, arg.BackoffMs, arg.ID)
+	return err
+}
+
+const pruneRegisteredPersistedQueries = `-- name: PruneRegisteredPersistedQueries :execrows
+DELETE FROM persisted_queries
+WHERE NOT imported AND hash IN (
+    SELECT hash FROM persisted_queries
+    WHERE NOT imported
+    ORDER BY created_at DESC, hash
+    OFFSET $1::int
+)
+`
+
+func (q *Queries) PruneRegisteredPersistedQueries(ctx context.Context, keep int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, pruneRegisteredPersistedQueries, keep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeAgents = `-- name: PurgeAgents :execrows
+DELETE FROM agents
+WHERE deleted_at < $1::timestamptz
+AND NOT EXISTS (SELECT 1 FROM authors WHERE authors.agent_id = agents.id)
+`
+
+func (q *Queries) PurgeAgents(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeAgents, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeAuthors = `-- name: PurgeAuthors :execrows
+DELETE FROM authors
+WHERE deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeAuthors(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeAuthors, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeBooks = `-- name: PurgeBooks :execrows
+DELETE FROM books
+WHERE deleted_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeBooks(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeBooks, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeDeliveredOutboxEvents = `-- name: PurgeDeliveredOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE delivered_at < $1::timestamptz
+`
+
+func (q *Queries) PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeliveredOutboxEvents, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreAgent = `-- name: RestoreAgent :one
+UPDATE agents
+SET deleted_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, email, deleted_at, version
+`
+
+func (q *Queries) RestoreAgent(ctx context.Context, id int64) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, restoreAgent, id)
+	var i Agent
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const restoreAuthor = `-- name: RestoreAuthor :one
+UPDATE authors
+SET deleted_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, website, agent_id, deleted_at, version
+`
+
+func (q *Queries) RestoreAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, restoreAuthor, id)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.AgentID,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const restoreBook = `-- name: RestoreBook :one
+UPDATE books
+SET deleted_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, title, description, cover, deleted_at, version
+`
+
+func (q *Queries) RestoreBook(ctx context.Context, id int64) (Book, error) {
+	row := q.db.QueryRowContext(ctx, restoreBook, id)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Cover,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const search = `-- name: Search :many
+WITH query AS (
+    SELECT websearch_to_tsquery('simple', $1::text) AS simple,
+        websearch_to_tsquery('english', $1::text) AS english
+), hits AS (
+    SELECT 'agent'::text AS entity, agents.id, ts_rank(to_tsvector('simple', agents.name), query.simple) AS rank,
+        agents.name AS document, 'simple'::regconfig AS config, query.simple AS tsquery
+    FROM agents, query
+    WHERE to_tsvector('simple', agents.name) @@ query.simple AND agents.deleted_at IS NULL
+    UNION ALL
+    SELECT 'author'::text, authors.id, ts_rank(to_tsvector('simple', authors.name), query.simple),
+        authors.name, 'simple'::regconfig, query.simple
+    FROM authors, query
+    WHERE to_tsvector('simple', authors.name) @@ query.simple AND authors.deleted_at IS NULL
+    UNION ALL
+    SELECT 'book'::text, books.id, ts_rank(
+        setweight(to_tsvector('english', books.title), 'A') ||
+        setweight(to_tsvector('english', books.description), 'B'), query.english),
+        books.title || ' ' || books.description, 'english'::regconfig, query.english
+    FROM books, query
+    WHERE (setweight(to_tsvector('english', books.title), 'A') ||
+        setweight(to_tsvector('english', books.description), 'B')) @@ query.english
+    AND books.deleted_at IS NULL
+), page AS (
+    SELECT hits.entity, hits.id, hits.rank, hits.document, hits.config, hits.tsquery
+    FROM hits
+    WHERE hits.entity = ANY($2::text[])
+    AND (NOT $3::bool OR (hits.rank, hits.entity, hits.id) < ($4::real, $5::text, $6::bigint))
+    ORDER BY hits.rank DESC, hits.entity DESC, hits.id DESC
+    LIMIT $7::int
+)
+SELECT page.entity::text AS entity, page.id::bigint AS id, page.rank::real AS rank,
+    ts_headline(page.config, page.document, page.tsquery, 'MaxFragments=2, MaxWords=20, MinWords=5')::text AS snippet
+FROM page
+ORDER BY page.rank DESC, page.entity DESC, page.id DESC
+`
+
+type SearchParams struct {
+	Query       string
+	Entities    []string
+	HasAfter    bool
+	AfterRank   float32
+	AfterEntity string
+	AfterID     int64
+	RowLimit    int32
+}
+
+type SearchRow struct {
+	Entity  string
+	ID      int64
+	Rank    float32
+	Snippet string
+}
+
+func (q *Queries) Search(ctx context.Context, arg SearchParams) ([]SearchRow, error) {
+	rows, err := q.db.QueryContext(ctx, search,
+		arg.Query,
+		pq.Array(arg.Entities),
+		arg.HasAfter,
+		arg.AfterRank,
+		arg.AfterEntity,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchRow
+	for rows.Next() {
+		var i SearchRow
+		if err := rows.Scan(
+			&i.Entity,
+			&i.ID,
+			&i.Rank,
+			&i.Snippet,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const setBookAuthor = `-- name: SetBookAuthor :exec
 INSERT INTO book_authors (book_id, author_id)
 VALUES ($1, $2)
@@ -440,6 +1351,7 @@ func (q *Queries) SetBookAuthor(ctx context.Context, arg SetBookAuthorParams) er
 const unsetBookAuthors = `-- name: UnsetBookAuthors :exec
 DELETE FROM book_authors
 WHERE book_id = $1
+AND author_id IN (SELECT id FROM authors WHERE deleted_at IS NULL)
 `
 
 func (q *Queries) UnsetBookAuthors(ctx context.Context, bookID int64) error {
@@ -449,9 +1361,9 @@ func (q *Queries) UnsetBookAuthors(ctx context.Context, bookID int64) error {
 
 const updateAgent = `-- name: UpdateAgent :one
 UPDATE agents
-SET name = $2, email = $3
-WHERE id = $1
-RETURNING id, name, email
+SET name = $2, email = $3, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, email, deleted_at, version
 `
 
 type UpdateAgentParams struct {
@@ -463,15 +1375,21 @@ type UpdateAgentParams struct {
 func (q *Queries) UpdateAgent(ctx context.Context, arg UpdateAgentParams) (Agent, error) {
 	row := q.db.QueryRowContext(ctx, updateAgent, arg.ID, arg.Name, arg.Email)
 	var i Agent
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.DeletedAt,
+		&i.Version,
+	)
 	return i, err
 }
 
 const updateAuthor = `-- name: UpdateAuthor :one
 UPDATE authors
-SET name = $2, website = $3, agent_id = $4
-WHERE id = $1
-RETURNING id, name, website, agent_id
+SET name = $2, website = $3, agent_id = $4, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, website, agent_id, deleted_at, version
 `
 
 type UpdateAuthorParams struct {
@@ -494,15 +1412,17 @@ func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Aut
 		&i.Name,
 		&i.Website,
 		&i.AgentID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const updateBook = `-- name: UpdateBook :one
 UPDATE books
-SET title = $2, description = $3, cover = $4
-WHERE id = $1
-RETURNING id, title, description, cover
+SET title = $2, description = $3, cover = $4, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, description, cover, deleted_at, version
 `
 
 type UpdateBookParams struct {
@@ -525,6 +1445,8 @@ func (q *Queries) UpdateBook(ctx context.Context, arg UpdateBookParams) (Book, e
 		&i.Title,
 		&i.Description,
 		&i.Cover,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -0,0 +1,148 @@
+package pg
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// SortByRank orders the search results by their rank.
+const SortByRank SortField = "rank"
+
+// SearchOrder is the order of the search results: best match first.
+var SearchOrder = Order{Field: SortByRank, Desc: true}
+
+// SearchResult is an entity matched by a search, an Agent, an Author or a
+// Book.
+type SearchResult interface {
+	isSearchResult()
+}
+
+func (Agent) isSearchResult()  {}
+func (Author) isSearchResult() {}
+func (Book) isSearchResult()   {}
+
+// SearchParams returns the arguments of the Search query. Only forward pages
+// are supported, the key of the cursor holds the rank and the entity of the
+// last result.
+func (p Page) SearchParams(query string, entities []string) (SearchParams, error) {
+	arg := SearchParams{
+		Query:    query,
+		Entities: entities,
+		HasAfter: p.HasAfter,
+		AfterID:  p.After.ID,
+		RowLimit: p.rowLimit(),
+	}
+	if p.HasAfter {
+		parts := strings.SplitN(p.After.Key, ":", 2)
+		if len(parts) != 2 {
+			return SearchParams{}, ErrInvalidCursor
+		}
+		rank, err := strconv.ParseFloat(parts[0], 32)
+		if err != nil {
+			return SearchParams{}, ErrInvalidCursor
+		}
+		arg.AfterRank, arg.AfterEntity = float32(rank), parts[1]
+	}
+	return arg, nil
+}
+
+// searchCursor returns the cursor of a row returned by the Search query.
+func searchCursor(row SearchRow) string {
+	key := strconv.FormatFloat(float64(row.Rank), 'g', -1, 32) + ":" + row.Entity
+	return Cursor{Order: SearchOrder, Key: key, ID: row.ID}.String()
+}
+
+// SearchEdge is an entity matched by a search together with its cursor, its
+// rank and a snippet of its text with the matched words highlighted.
+type SearchEdge struct {
+	Cursor  string
+	Node    SearchResult
+	Rank    float64
+	Snippet string
+}
+
+// SearchConnection is a page of search results, ordered by decreasing rank.
+// It has no total count, which would have to rank every match.
+type SearchConnection struct {
+	Edges    []SearchEdge
+	PageInfo PageInfo
+}
+
+// SearchEntities runs the Search query with the arguments of page and loads
+// the matched entities. Rows whose entity has been deleted in the meantime
+// are skipped.
+func SearchEntities(ctx context.Context, repo Repository, query string, entities []string, page Page) (*SearchConnection, error) {
+	arg, err := page.SearchParams(query, entities)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := repo.Search(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	n, pageInfo := page.window(len(rows))
+	rows = rows[:n]
+	ids := make(map[string][]int64)
+	for _, row := range rows {
+		ids[row.Entity] = append(ids[row.Entity], row.ID)
+	}
+	nodes := make(map[string]map[int64]SearchResult)
+	add := func(entity string, id int64, node SearchResult) {
+		if nodes[entity] == nil {
+			nodes[entity] = make(map[int64]SearchResult)
+		}
+		nodes[entity][id] = node
+	}
+	if len(ids[EntityAgent]) > 0 {
+		agents, err := repo.ListAgentsByIDs(ctx, ids[EntityAgent])
+		if err != nil {
+			return nil, err
+		}
+		for _, agent := range agents {
+			if !agent.DeletedAt.Valid {
+				add(EntityAgent, agent.ID, agent)
+			}
+		}
+	}
+	if len(ids[EntityAuthor]) > 0 {
+		authors, err := repo.ListAuthorsByIDs(ctx, ids[EntityAuthor])
+		if err != nil {
+			return nil, err
+		}
+		for _, author := range authors {
+			if !author.DeletedAt.Valid {
+				add(EntityAuthor, author.ID, author)
+			}
+		}
+	}
+	if len(ids[EntityBook]) > 0 {
+		books, err := repo.ListBooksByIDs(ctx, ids[EntityBook])
+		if err != nil {
+			return nil, err
+		}
+		for _, book := range books {
+			if !book.DeletedAt.Valid {
+				add(EntityBook, book.ID, book)
+			}
+		}
+	}
+	conn := &SearchConnection{PageInfo: pageInfo}
+	for _, row := range rows {
+		node, ok := nodes[row.Entity][row.ID]
+		if !ok {
+			continue
+		}
+		conn.Edges = append(conn.Edges, SearchEdge{
+			Cursor:  searchCursor(row),
+			Node:    node,
+			Rank:    float64(row.Rank),
+			Snippet: row.Snippet,
+		})
+	}
+	if n := len(conn.Edges); n > 0 {
+		conn.PageInfo.StartCursor = &conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = &conn.Edges[n-1].Cursor
+	}
+	return conn, nil
+}
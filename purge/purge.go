@@ -0,0 +1,95 @@
+// Package purge removes the deleted entities for good.
+//
+// Deleting an entity through pg.Repository only marks it deleted, so that it
+// can be restored together with its associations. The Job purges the
+// entities once they have been deleted for longer than the retention period,
+// after which they can no longer be restored. It also prunes the outbox
+// events delivered for longer than their own retention period.
+package purge
+
+import (
+	"context"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/logging" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"      // update the username
+)
+
+// Store holds the deleted entities and the outbox events, it is implemented
+// by pg.Repository.
+type Store interface {
+	PurgeDeleted(ctx context.Context, before time.Time) (pg.PurgeResult, error)
+	PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// DefaultInterval is how often the Job purges by default.
+const DefaultInterval = time.Hour
+
+// Job periodically purges the entities deleted for longer than Retention
+// and the outbox events delivered for longer than OutboxRetention, a zero
+// retention disables the respective purge. Several jobs, e.g. of different
+// servers, may share a store.
+type Job struct {
+	Store           Store
+	Logger          *logging.Logger
+	Retention       time.Duration
+	OutboxRetention time.Duration
+	// Interval is how often the deleted entities and the delivered events are
+	// purged.
+	Interval time.Duration
+}
+
+// Run purges the deleted entities and the delivered events, once
+// immediately and then every Interval, until ctx is done.
+func (j *Job) Run(ctx context.Context) {
+	interval := j.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		j.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *Job) purge(ctx context.Context) {
+	if j.Retention > 0 {
+		j.purgeDeleted(ctx)
+	}
+	if j.OutboxRetention > 0 {
+		j.purgeOutbox(ctx)
+	}
+}
+
+func (j *Job) purgeDeleted(ctx context.Context) {
+	res, err := j.Store.PurgeDeleted(ctx, time.Now().Add(-j.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			j.Logger.Warn("purge failed", "error", err.Error())
+		}
+		return
+	}
+	if res.Agents+res.Authors+res.Books > 0 {
+		j.Logger.Info("purged deleted entities",
+			"agents", res.Agents, "authors", res.Authors, "books", res.Books)
+	}
+}
+
+func (j *Job) purgeOutbox(ctx context.Context) {
+	n, err := j.Store.PurgeDeliveredOutboxEvents(ctx, time.Now().Add(-j.OutboxRetention))
+	if err != nil {
+		if ctx.Err() == nil {
+			j.Logger.Warn("outbox purge failed", "error", err.Error())
+		}
+		return
+	}
+	if n > 0 {
+		j.Logger.Info("purged delivered outbox events", "events", n)
+	}
+}
@@ -1,74 +1,95 @@
 -- name: GetAgent :one
-SELECT * FROM agents
-WHERE id = $1;
-
--- name: ListAgents :many
-SELECT * FROM agents
-ORDER BY name;
+SELECT id, name, email, deleted_at, version FROM agents
+WHERE id = sqlc.arg(id) AND (deleted_at IS NULL OR sqlc.arg(include_deleted)::bool);
 
 -- name: CreateAgent :one
 INSERT INTO agents (name, email)
 VALUES ($1, $2)
-RETURNING *;
+RETURNING id, name, email, deleted_at, version;
 
 -- name: UpdateAgent :one
 UPDATE agents
-SET name = $2, email = $3
-WHERE id = $1
-RETURNING *;
+SET name = $2, email = $3, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, email, deleted_at, version;
 
 -- name: DeleteAgent :one
-DELETE FROM agents
-WHERE id = $1
-RETURNING *;
+UPDATE agents
+SET deleted_at = now(), version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, email, deleted_at, version;
 
--- name: GetAuthor :one
-SELECT * FROM authors
-WHERE id = $1;
+-- name: RestoreAgent :one
+UPDATE agents
+SET deleted_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, email, deleted_at, version;
 
--- name: ListAuthors :many
-SELECT * FROM authors
-ORDER BY name;
+-- name: ListAgentsByIDs :many
+SELECT id, name, email, deleted_at, version FROM agents
+WHERE id = ANY($1::bigint[]);
+
+-- name: GetAuthor :one
+SELECT id, name, website, agent_id, deleted_at, version FROM authors
+WHERE id = sqlc.arg(id) AND (deleted_at IS NULL OR sqlc.arg(include_deleted)::bool);
 
 -- name: CreateAuthor :one
 INSERT INTO authors (name, website, agent_id)
 VALUES ($1, $2, $3)
-RETURNING *;
+RETURNING id, name, website, agent_id, deleted_at, version;
 
 -- name: UpdateAuthor :one
 UPDATE authors
-SET name = $2, website = $3, agent_id = $4
-WHERE id = $1
-RETURNING *;
+SET name = $2, website = $3, agent_id = $4, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, website, agent_id, deleted_at, version;
 
 -- name: DeleteAuthor :one
-DELETE FROM authors
-WHERE id = $1
-RETURNING *;
+UPDATE authors
+SET deleted_at = now(), version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, website, agent_id, deleted_at, version;
 
--- name: GetBook :one
-SELECT * FROM books
-WHERE id = $1;
+-- name: RestoreAuthor :one
+UPDATE authors
+SET deleted_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, website, agent_id, deleted_at, version;
+
+-- name: ListAuthorsByIDs :many
+SELECT id, name, website, agent_id, deleted_at, version FROM authors
+WHERE id = ANY($1::bigint[]);
 
--- name: ListBooks :many
-SELECT * FROM books
-ORDER BY title;
+-- name: GetBook :one
+SELECT id, title, description, cover, deleted_at, version FROM books
+WHERE id = sqlc.arg(id) AND (deleted_at IS NULL OR sqlc.arg(include_deleted)::bool);
 
 -- name: CreateBook :one
 INSERT INTO books (title, description, cover)
 VALUES ($1, $2, $3)
-RETURNING *;
+RETURNING id, title, description, cover, deleted_at, version;
 
 -- name: UpdateBook :one
 UPDATE books
-SET title = $2, description = $3, cover = $4
-WHERE id = $1
-RETURNING *;
+SET title = $2, description = $3, cover = $4, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, description, cover, deleted_at, version;
 
 -- name: DeleteBook :one
-DELETE FROM books
-WHERE id = $1
-RETURNING *;
+UPDATE books
+SET deleted_at = now(), version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, title, description, cover, deleted_at, version;
+
+-- name: RestoreBook :one
+UPDATE books
+SET deleted_at = NULL, version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, title, description, cover, deleted_at, version;
+
+-- name: ListBooksByIDs :many
+SELECT id, title, description, cover, deleted_at, version FROM books
+WHERE id = ANY($1::bigint[]);
 
 -- name: SetBookAuthor :exec
 INSERT INTO book_authors (book_id, author_id)
@@ -76,20 +97,241 @@ VALUES ($1, $2);
 
 -- name: UnsetBookAuthors :exec
 DELETE FROM book_authors
-WHERE book_id = $1;
+WHERE book_id = $1
+AND author_id IN (SELECT id FROM authors WHERE deleted_at IS NULL);
+
+-- name: ListAuthorsByAgentIDsPage :many
+SELECT id, name, website, agent_id, version FROM (
+    SELECT authors.id, authors.name, authors.website, authors.agent_id, authors.deleted_at, authors.version, ROW_NUMBER() OVER (
+        PARTITION BY authors.agent_id
+        ORDER BY
+            CASE WHEN sqlc.arg(reverse)::bool THEN authors.name END DESC,
+            CASE WHEN sqlc.arg(reverse)::bool THEN authors.id END DESC,
+            authors.name, authors.id
+    ) AS row_number
+    FROM authors
+    WHERE authors.agent_id = ANY(sqlc.arg(agent_ids)::bigint[]) AND authors.deleted_at IS NULL
+    AND (NOT sqlc.arg(has_after)::bool OR (authors.name, authors.id) > (sqlc.arg(after_key)::text, sqlc.arg(after_id)::bigint))
+    AND (NOT sqlc.arg(has_before)::bool OR (authors.name, authors.id) < (sqlc.arg(before_key)::text, sqlc.arg(before_id)::bigint))
+) AS page
+WHERE page.row_number <= sqlc.arg(row_limit)::int
+ORDER BY page.agent_id, page.row_number;
+
+-- name: CountAuthorsByAgentIDs :many
+SELECT agent_id, COUNT(*) AS count FROM authors
+WHERE agent_id = ANY($1::bigint[]) AND deleted_at IS NULL
+GROUP BY agent_id;
 
--- name: ListAuthorsByAgentIDs :many
-SELECT authors.* FROM authors, agents
-WHERE authors.agent_id = agents.id AND agents.id = ANY($1::bigint[]);
+-- name: ListBooksByAuthorIDsPage :many
+SELECT id, title, description, cover, version, author_id FROM (
+    SELECT books.id, books.title, books.description, books.cover, books.deleted_at, books.version, book_authors.author_id, ROW_NUMBER() OVER (
+        PARTITION BY book_authors.author_id
+        ORDER BY
+            CASE WHEN sqlc.arg(reverse)::bool THEN books.title END DESC,
+            CASE WHEN sqlc.arg(reverse)::bool THEN books.id END DESC,
+            books.title, books.id
+    ) AS row_number
+    FROM books, book_authors
+    WHERE book_authors.book_id = books.id AND book_authors.author_id = ANY(sqlc.arg(author_ids)::bigint[])
+    AND books.deleted_at IS NULL
+    AND (NOT sqlc.arg(has_after)::bool OR (books.title, books.id) > (sqlc.arg(after_key)::text, sqlc.arg(after_id)::bigint))
+    AND (NOT sqlc.arg(has_before)::bool OR (books.title, books.id) < (sqlc.arg(before_key)::text, sqlc.arg(before_id)::bigint))
+) AS page
+WHERE page.row_number <= sqlc.arg(row_limit)::int
+ORDER BY page.author_id, page.row_number;
 
--- name: ListBooksByAuthorIDs :many
-SELECT books.*, book_authors.author_id FROM books, book_authors
-WHERE book_authors.book_id = books.id AND book_authors.author_id = ANY($1::bigint[]);
+-- name: CountBooksByAuthorIDs :many
+SELECT book_authors.author_id, COUNT(*) AS count FROM book_authors, books
+WHERE book_authors.book_id = books.id AND book_authors.author_id = ANY($1::bigint[])
+AND books.deleted_at IS NULL
+GROUP BY book_authors.author_id;
 
--- name: ListAuthorsByBookIDs :many
-SELECT authors.*, book_authors.book_id FROM authors, book_authors
-WHERE book_authors.author_id = authors.id AND book_authors.book_id = ANY($1::bigint[]);
+-- name: ListAuthorsByBookIDsPage :many
+SELECT id, name, website, agent_id, version, book_id FROM (
+    SELECT authors.id, authors.name, authors.website, authors.agent_id, authors.deleted_at, authors.version, book_authors.book_id, ROW_NUMBER() OVER (
+        PARTITION BY book_authors.book_id
+        ORDER BY
+            CASE WHEN sqlc.arg(reverse)::bool THEN authors.name END DESC,
+            CASE WHEN sqlc.arg(reverse)::bool THEN authors.id END DESC,
+            authors.name, authors.id
+    ) AS row_number
+    FROM authors, book_authors
+    WHERE book_authors.author_id = authors.id AND book_authors.book_id = ANY(sqlc.arg(book_ids)::bigint[])
+    AND authors.deleted_at IS NULL
+    AND (NOT sqlc.arg(has_after)::bool OR (authors.name, authors.id) > (sqlc.arg(after_key)::text, sqlc.arg(after_id)::bigint))
+    AND (NOT sqlc.arg(has_before)::bool OR (authors.name, authors.id) < (sqlc.arg(before_key)::text, sqlc.arg(before_id)::bigint))
+) AS page
+WHERE page.row_number <= sqlc.arg(row_limit)::int
+ORDER BY page.book_id, page.row_number;
+
+-- name: CountAuthorsByBookIDs :many
+SELECT book_authors.book_id, COUNT(*) AS count FROM book_authors, authors
+WHERE book_authors.author_id = authors.id AND book_authors.book_id = ANY($1::bigint[])
+AND authors.deleted_at IS NULL
+GROUP BY book_authors.book_id;
 
 -- name: ListAgentsByAuthorIDs :many
-SELECT agents.*, authors.id AS author_id FROM agents, authors
-WHERE agents.id = authors.agent_id AND authors.id  = ANY($1::bigint[]);
\ No newline at end of file
+SELECT agents.id, agents.name, agents.email, agents.deleted_at, agents.version,
+    authors.id AS author_id FROM agents, authors
+WHERE agents.id = authors.agent_id AND authors.id = ANY($1::bigint[]);
+
+-- name: GetPersistedQuery :one
+SELECT query FROM persisted_queries
+WHERE hash = $1;
+
+-- name: GetImportedPersistedQuery :one
+SELECT query FROM persisted_queries
+WHERE hash = $1 AND imported;
+
+-- name: CreatePersistedQuery :exec
+INSERT INTO persisted_queries (hash, query)
+VALUES ($1, $2)
+ON CONFLICT (hash) DO NOTHING;
+
+-- name: ImportPersistedQuery :exec
+INSERT INTO persisted_queries (hash, query, imported)
+VALUES ($1, $2, true)
+ON CONFLICT (hash) DO UPDATE SET imported = true;
+
+-- name: PruneRegisteredPersistedQueries :execrows
+DELETE FROM persisted_queries
+WHERE NOT imported AND hash IN (
+    SELECT hash FROM persisted_queries
+    WHERE NOT imported
+    ORDER BY created_at DESC, hash
+    OFFSET sqlc.arg(keep)::int
+);
+
+-- name: GetAgentForUpdate :one
+SELECT id, name, email, deleted_at, version FROM agents
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: GetAuthorForUpdate :one
+SELECT id, name, website, agent_id, deleted_at, version FROM authors
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: GetBookForUpdate :one
+SELECT id, title, description, cover, deleted_at, version FROM books
+WHERE id = $1 AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: ListAuthorIDsByBookID :many
+SELECT book_authors.author_id FROM book_authors, authors
+WHERE book_authors.author_id = authors.id AND book_authors.book_id = $1
+AND authors.deleted_at IS NULL
+ORDER BY book_authors.author_id;
+
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (entity, entity_id, op, before, after, actor)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ClaimOutboxEvents :many
+UPDATE outbox_events
+SET next_attempt_at = now() + sqlc.arg(lease_ms)::bigint * interval '1 millisecond'
+WHERE id IN (
+    SELECT id FROM outbox_events
+    WHERE delivered_at IS NULL AND next_attempt_at <= now()
+    ORDER BY id
+    LIMIT sqlc.arg(batch_size)::int
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, entity, entity_id, op, before, after, actor, created_at, attempts,
+    next_attempt_at, last_error, delivered_at;
+
+-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox_events
+SET delivered_at = now(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox_events
+SET attempts = attempts + 1, last_error = sqlc.arg(last_error),
+    next_attempt_at = now() + sqlc.arg(backoff_ms)::bigint * interval '1 millisecond'
+WHERE id = sqlc.arg(id);
+
+-- name: PurgeDeliveredOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE delivered_at < sqlc.arg(before)::timestamptz;
+
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_entries (entity, entity_id, op, actor, changes)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListAuditEntriesByEntityIDsPage :many
+SELECT id, entity, entity_id, op, actor, changed_at, changes FROM (
+    SELECT audit_entries.id, audit_entries.entity, audit_entries.entity_id, audit_entries.op,
+        audit_entries.actor, audit_entries.changed_at, audit_entries.changes, ROW_NUMBER() OVER (
+        PARTITION BY audit_entries.entity_id
+        ORDER BY audit_entries.id DESC
+    ) AS row_number
+    FROM audit_entries
+    WHERE audit_entries.entity = sqlc.arg(entity) AND audit_entries.entity_id = ANY(sqlc.arg(entity_ids)::bigint[])
+    AND (NOT sqlc.arg(has_after)::bool OR audit_entries.id < sqlc.arg(after_id)::bigint)
+) AS page
+WHERE page.row_number <= sqlc.arg(row_limit)::int
+ORDER BY page.entity_id, page.row_number;
+
+-- name: CountLiveAuthorsByAgentID :one
+SELECT COUNT(*) FROM authors
+WHERE agent_id = $1 AND deleted_at IS NULL;
+
+-- name: LockAgentForShare :one
+SELECT id, name, email, deleted_at, version FROM agents
+WHERE id = $1 AND deleted_at IS NULL
+FOR SHARE;
+
+-- name: LockAuthorsForShare :many
+SELECT id FROM authors
+WHERE id = ANY($1::bigint[]) AND deleted_at IS NULL
+FOR SHARE;
+
+-- name: PurgeBooks :execrows
+DELETE FROM books
+WHERE deleted_at < sqlc.arg(before)::timestamptz;
+
+-- name: PurgeAuthors :execrows
+DELETE FROM authors
+WHERE deleted_at < sqlc.arg(before)::timestamptz;
+
+-- name: PurgeAgents :execrows
+DELETE FROM agents
+WHERE deleted_at < sqlc.arg(before)::timestamptz
+AND NOT EXISTS (SELECT 1 FROM authors WHERE authors.agent_id = agents.id);
+
+-- name: Search :many
+WITH query AS (
+    SELECT websearch_to_tsquery('simple', sqlc.arg(query)::text) AS simple,
+        websearch_to_tsquery('english', sqlc.arg(query)::text) AS english
+), hits AS (
+    SELECT 'agent'::text AS entity, agents.id, ts_rank(to_tsvector('simple', agents.name), query.simple) AS rank,
+        agents.name AS document, 'simple'::regconfig AS config, query.simple AS tsquery
+    FROM agents, query
+    WHERE to_tsvector('simple', agents.name) @@ query.simple AND agents.deleted_at IS NULL
+    UNION ALL
+    SELECT 'author'::text, authors.id, ts_rank(to_tsvector('simple', authors.name), query.simple),
+        authors.name, 'simple'::regconfig, query.simple
+    FROM authors, query
+    WHERE to_tsvector('simple', authors.name) @@ query.simple AND authors.deleted_at IS NULL
+    UNION ALL
+    SELECT 'book'::text, books.id, ts_rank(
+        setweight(to_tsvector('english', books.title), 'A') ||
+        setweight(to_tsvector('english', books.description), 'B'), query.english),
+        books.title || ' ' || books.description, 'english'::regconfig, query.english
+    FROM books, query
+    WHERE (setweight(to_tsvector('english', books.title), 'A') ||
+        setweight(to_tsvector('english', books.description), 'B')) @@ query.english
+    AND books.deleted_at IS NULL
+), page AS (
+    SELECT hits.entity, hits.id, hits.rank, hits.document, hits.config, hits.tsquery
+    FROM hits
+    WHERE hits.entity = ANY(sqlc.arg(entities)::text[])
+    AND (NOT sqlc.arg(has_after)::bool OR (hits.rank, hits.entity, hits.id) < (sqlc.arg(after_rank)::real, sqlc.arg(after_entity)::text, sqlc.arg(after_id)::bigint))
+    ORDER BY hits.rank DESC, hits.entity DESC, hits.id DESC
+    LIMIT sqlc.arg(row_limit)::int
+)
+SELECT page.entity::text AS entity, page.id::bigint AS id, page.rank::real AS rank,
+    ts_headline(page.config, page.document, page.tsquery, 'MaxFragments=2, MaxWords=20, MinWords=5')::text AS snippet
+FROM page
+ORDER BY page.rank DESC, page.entity DESC, page.id DESC;
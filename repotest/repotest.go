@@ -0,0 +1,422 @@
+// Package repotest provides a conformance suite for the implementations of
+// pg.Repository, checking that they apply the constraints of the pg
+// migrations and report their violations with the same errors.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+)
+
+// Run runs the suite against the repositories returned by newRepo, which is
+// called once per test and must return an empty repository whose ID
+// sequences start at 1.
+func Run(t *testing.T, newRepo func(t *testing.T) pg.Repository) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo pg.Repository)
+	}{
+		{"Sequences", testSequences},
+		{"NotFound", testNotFound},
+		{"InvalidReference", testInvalidReference},
+		{"RestrictAgentDelete", testRestrictAgentDelete},
+		{"UniqueBookAuthors", testUniqueBookAuthors},
+		{"AddedAuthors", testAddedAuthors},
+		{"CascadePurge", testCascadePurge},
+		{"OutboxPurge", testOutboxPurge},
+		{"Search", testSearch},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepo(t))
+		})
+	}
+}
+
+func testSequences(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	for want := int64(1); want <= 3; want++ {
+		agent := createAgent(t, repo, "agent")
+		if agent.ID != want {
+			t.Fatalf("agent ID = %d, want %d", agent.ID, want)
+		}
+	}
+	// the sequences are independent
+	author := createAuthor(t, repo, 1)
+	if author.ID != 1 {
+		t.Fatalf("author ID = %d, want 1", author.ID)
+	}
+	book := createBook(t, repo, author.ID)
+	if book.ID != 1 {
+		t.Fatalf("book ID = %d, want 1", book.ID)
+	}
+	// deleted rows keep their IDs
+	if _, err := repo.DeleteBook(ctx, book.ID); err != nil {
+		t.Fatal(err)
+	}
+	if book := createBook(t, repo, author.ID); book.ID != 2 {
+		t.Fatalf("book ID = %d, want 2", book.ID)
+	}
+}
+
+func testNotFound(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	agent := createAgent(t, repo, "agent")
+	check := func(op string, err error) {
+		t.Helper()
+		if !errors.Is(err, pg.ErrNotFound) {
+			t.Errorf("%s: got %v, want %v", op, err, pg.ErrNotFound)
+		}
+	}
+	_, err := repo.GetAgent(ctx, pg.GetAgentParams{ID: 99})
+	check("GetAgent", err)
+	_, err = repo.UpdateAgent(ctx, pg.UpdateAgentParams{ID: 99, Name: "agent", Email: "agent@example.com"}, nil)
+	check("UpdateAgent", err)
+	_, err = repo.DeleteAgent(ctx, 99)
+	check("DeleteAgent", err)
+	_, err = repo.RestoreAgent(ctx, agent.ID)
+	check("RestoreAgent of a live agent", err)
+	_, err = repo.GetAuthor(ctx, pg.GetAuthorParams{ID: 99})
+	check("GetAuthor", err)
+	_, err = repo.DeleteAuthor(ctx, 99)
+	check("DeleteAuthor", err)
+	_, err = repo.GetBook(ctx, pg.GetBookParams{ID: 99})
+	check("GetBook", err)
+	_, _, err = repo.UpdateBook(ctx, pg.UpdateBookParams{ID: 99, Title: "book"}, nil, nil)
+	check("UpdateBook", err)
+	_, err = repo.DeleteBook(ctx, 99)
+	check("DeleteBook", err)
+
+	// deleted entities are only found when asked for
+	if _, err := repo.DeleteAgent(ctx, agent.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = repo.GetAgent(ctx, pg.GetAgentParams{ID: agent.ID})
+	check("GetAgent of a deleted agent", err)
+	_, err = repo.DeleteAgent(ctx, agent.ID)
+	check("DeleteAgent of a deleted agent", err)
+	_, err = repo.UpdateAgent(ctx, pg.UpdateAgentParams{ID: agent.ID, Name: "agent", Email: "agent@example.com"}, nil)
+	check("UpdateAgent of a deleted agent", err)
+	if _, err := repo.GetAgent(ctx, pg.GetAgentParams{ID: agent.ID, IncludeDeleted: true}); err != nil {
+		t.Errorf("GetAgent including deleted: %v", err)
+	}
+}
+
+func testInvalidReference(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	_, err := repo.CreateAuthor(ctx, pg.CreateAuthorParams{Name: "author", AgentID: 99})
+	if !errors.Is(err, pg.ErrInvalidReference) {
+		t.Errorf("CreateAuthor with a missing agent: got %v, want %v", err, pg.ErrInvalidReference)
+	}
+	agent := createAgent(t, repo, "agent")
+	author := createAuthor(t, repo, agent.ID)
+	_, err = repo.CreateBook(ctx, pg.CreateBookParams{Title: "book"}, []int64{author.ID, 99})
+	if !errors.Is(err, pg.ErrInvalidReference) {
+		t.Errorf("CreateBook with a missing author: got %v, want %v", err, pg.ErrInvalidReference)
+	}
+	// deleted rows cannot be referenced
+	if _, err := repo.DeleteAuthor(ctx, author.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = repo.CreateBook(ctx, pg.CreateBookParams{Title: "book"}, []int64{author.ID})
+	if !errors.Is(err, pg.ErrInvalidReference) {
+		t.Errorf("CreateBook with a deleted author: got %v, want %v", err, pg.ErrInvalidReference)
+	}
+}
+
+func testRestrictAgentDelete(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	agent := createAgent(t, repo, "agent")
+	author := createAuthor(t, repo, agent.ID)
+	_, err := repo.DeleteAgent(ctx, agent.ID)
+	if !errors.Is(err, pg.ErrConflict) {
+		t.Fatalf("DeleteAgent of a referenced agent: got %v, want %v", err, pg.ErrConflict)
+	}
+	var cErr *pg.ConstraintError
+	if !errors.As(err, &cErr) || cErr.Constraint != "authors_agent_id_fkey" {
+		t.Errorf("DeleteAgent of a referenced agent: got %#v, want a violation of authors_agent_id_fkey", err)
+	}
+	// deleted authors do not restrict the delete, only the purge
+	if _, err := repo.DeleteAuthor(ctx, author.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.DeleteAgent(ctx, agent.ID); err != nil {
+		t.Fatalf("DeleteAgent of an agent of deleted authors: %v", err)
+	}
+	// the author cannot be restored while its agent is deleted
+	_, err = repo.RestoreAuthor(ctx, author.ID)
+	if !errors.Is(err, pg.ErrInvalidReference) {
+		t.Errorf("RestoreAuthor of an author of a deleted agent: got %v, want %v", err, pg.ErrInvalidReference)
+	}
+}
+
+func testUniqueBookAuthors(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	agent := createAgent(t, repo, "agent")
+	author := createAuthor(t, repo, agent.ID)
+	_, err := repo.CreateBook(ctx, pg.CreateBookParams{Title: "book"}, []int64{author.ID, author.ID})
+	if !errors.Is(err, pg.ErrConflict) {
+		t.Errorf("CreateBook with a duplicate author: got %v, want %v", err, pg.ErrConflict)
+	}
+	book := createBook(t, repo, author.ID)
+	_, _, err = repo.UpdateBook(ctx, pg.UpdateBookParams{ID: book.ID, Title: "book"}, []int64{author.ID, author.ID}, nil)
+	if !errors.Is(err, pg.ErrConflict) {
+		t.Errorf("UpdateBook with a duplicate author: got %v, want %v", err, pg.ErrConflict)
+	}
+	// the failed update changed nothing
+	if got := countBooks(t, repo, author.ID); got != 1 {
+		t.Errorf("author has %d books, want 1", got)
+	}
+}
+
+func testAddedAuthors(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	agent := createAgent(t, repo, "agent")
+	kept := createAuthor(t, repo, agent.ID)
+	removed := createAuthor(t, repo, agent.ID)
+	added := createAuthor(t, repo, agent.ID)
+	book := createBook(t, repo, kept.ID, removed.ID)
+	_, got, err := repo.UpdateBook(ctx, pg.UpdateBookParams{ID: book.ID, Title: "book"}, []int64{kept.ID, added.ID}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != added.ID {
+		t.Errorf("UpdateBook added authors %v, want [%d]", got, added.ID)
+	}
+}
+
+func testCascadePurge(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	agent := createAgent(t, repo, "agent")
+	kept := createAuthor(t, repo, agent.ID)
+	purged := createAuthor(t, repo, agent.ID)
+	book := createBook(t, repo, kept.ID, purged.ID)
+	other := createBook(t, repo, kept.ID, purged.ID)
+	if _, err := repo.DeleteAuthor(ctx, purged.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.DeleteBook(ctx, other.ID); err != nil {
+		t.Fatal(err)
+	}
+	res, err := repo.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (pg.PurgeResult{Authors: 1, Books: 1}); res != want {
+		t.Errorf("PurgeDeleted = %+v, want %+v", res, want)
+	}
+	// the links of the purged rows are gone, the others are kept
+	if got := countBooks(t, repo, kept.ID); got != 1 {
+		t.Errorf("kept author has %d books, want 1", got)
+	}
+	counts, err := repo.CountAuthorsByBookIDs(ctx, []int64{book.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 1 || counts[0].Count != 1 {
+		t.Errorf("CountAuthorsByBookIDs = %+v, want 1 author", counts)
+	}
+	if _, err := repo.RestoreAuthor(ctx, purged.ID); !errors.Is(err, pg.ErrNotFound) {
+		t.Errorf("RestoreAuthor of a purged author: got %v, want %v", err, pg.ErrNotFound)
+	}
+	// the agent is purged once it is deleted and its authors are gone
+	if _, err := repo.DeleteAuthor(ctx, kept.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.DeleteAgent(ctx, agent.ID); err != nil {
+		t.Fatal(err)
+	}
+	res, err = repo.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (pg.PurgeResult{Agents: 1, Authors: 1}); res != want {
+		t.Errorf("PurgeDeleted = %+v, want %+v", res, want)
+	}
+}
+
+func testOutboxPurge(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	createAgent(t, repo, "delivered")
+	createAgent(t, repo, "pending")
+	events, err := repo.ClaimOutboxEvents(ctx, pg.ClaimOutboxEventsParams{LeaseMs: 60000, BatchSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("ClaimOutboxEvents returned %d events, want 1", len(events))
+	}
+	if err := repo.MarkOutboxEventDelivered(ctx, events[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	// only the delivered event is purged
+	n, err := repo.PurgeDeliveredOutboxEvents(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("PurgeDeliveredOutboxEvents = %d, want 1", n)
+	}
+	n, err = repo.PurgeDeliveredOutboxEvents(ctx, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("PurgeDeliveredOutboxEvents of the pending event = %d, want 0", n)
+	}
+}
+
+func testSearch(t *testing.T, repo pg.Repository) {
+	ctx := context.Background()
+	agent := createAgent(t, repo, "lovelace")
+	author, err := repo.CreateAuthor(ctx, pg.CreateAuthorParams{Name: "Ada Lovelace", AgentID: agent.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	titled, err := repo.CreateBook(ctx, pg.CreateBookParams{Title: "Lovelace", Description: "notes on the engine"}, []int64{author.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	described, err := repo.CreateBook(ctx, pg.CreateBookParams{Title: "Engine", Description: "notes on lovelace"}, []int64{author.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	createBook(t, repo, author.ID)
+	all := []string{pg.EntityAgent, pg.EntityAuthor, pg.EntityBook}
+	search := func(query string, entities []string, first int, after *string) *pg.SearchConnection {
+		t.Helper()
+		page, err := pg.NewPage(pg.SearchOrder, &first, after, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, err := pg.SearchEntities(ctx, repo, query, entities, page)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+	results := func(conn *pg.SearchConnection) []string {
+		var results []string
+		for _, edge := range conn.Edges {
+			switch node := edge.Node.(type) {
+			case pg.Agent:
+				results = append(results, fmt.Sprintf("agent %d", node.ID))
+			case pg.Author:
+				results = append(results, fmt.Sprintf("author %d", node.ID))
+			case pg.Book:
+				results = append(results, fmt.Sprintf("book %d", node.ID))
+			}
+		}
+		return results
+	}
+
+	// the results of every entity are ranked together, the ranks of the
+	// entities are not comparable across the repositories
+	conn := search("LOVELACE", all, pg.MaxPageSize, nil)
+	got := results(conn)
+	sorted := append([]string(nil), got...)
+	sort.Strings(sorted)
+	want := []string{
+		fmt.Sprintf("agent %d", agent.ID),
+		fmt.Sprintf("author %d", author.ID),
+		fmt.Sprintf("book %d", titled.ID),
+		fmt.Sprintf("book %d", described.ID),
+	}
+	if fmt.Sprint(sorted) != fmt.Sprint(want) {
+		t.Fatalf("search results = %v, want %v", got, want)
+	}
+	for i := 1; i < len(conn.Edges); i++ {
+		if conn.Edges[i].Rank > conn.Edges[i-1].Rank {
+			t.Errorf("search results = %v, not ordered by decreasing rank", got)
+		}
+	}
+	if conn.PageInfo.HasNextPage || conn.PageInfo.HasPreviousPage {
+		t.Errorf("page info = %+v, want a single page", conn.PageInfo)
+	}
+
+	// the matches in the title rank above those in the description
+	conn = search("lovelace", []string{pg.EntityBook}, pg.MaxPageSize, nil)
+	want = []string{fmt.Sprintf("book %d", titled.ID), fmt.Sprintf("book %d", described.ID)}
+	if got := results(conn); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("book results = %v, want %v", got, want)
+	}
+	for _, edge := range conn.Edges {
+		if edge.Rank <= 0 || edge.Snippet == "" {
+			t.Errorf("edge %+v, want a rank and a snippet", edge)
+		}
+	}
+
+	// all words of the query must match
+	if got := results(search("lovelace notes", all, pg.MaxPageSize, nil)); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("results of two words = %v, want %v", got, want)
+	}
+	for _, query := range []string{"", "  "} {
+		if conn := search(query, all, pg.MaxPageSize, nil); len(conn.Edges) != 0 || conn.PageInfo.HasNextPage {
+			t.Errorf("results of %q = %v, want none", query, results(conn))
+		}
+	}
+
+	// the pages follow each other in the order of the results
+	var paged []string
+	var after *string
+	for i := 0; ; i++ {
+		if i > 4 {
+			t.Fatalf("pages = %v, want 4 pages", paged)
+		}
+		conn := search("lovelace", all, 1, after)
+		paged = append(paged, results(conn)...)
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		after = conn.PageInfo.EndCursor
+	}
+	if fmt.Sprint(paged) != fmt.Sprint(got) {
+		t.Errorf("paged results = %v, want %v", paged, got)
+	}
+}
+
+func createAgent(t *testing.T, repo pg.Repository, name string) pg.Agent {
+	t.Helper()
+	agent, err := repo.CreateAgent(context.Background(), pg.CreateAgentParams{Name: name, Email: name + "@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return agent
+}
+
+func createAuthor(t *testing.T, repo pg.Repository, agentID int64) pg.Author {
+	t.Helper()
+	author, err := repo.CreateAuthor(context.Background(), pg.CreateAuthorParams{Name: "author", AgentID: agentID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return author
+}
+
+func createBook(t *testing.T, repo pg.Repository, authorIDs ...int64) *pg.Book {
+	t.Helper()
+	book, err := repo.CreateBook(context.Background(), pg.CreateBookParams{Title: "book"}, authorIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return book
+}
+
+func countBooks(t *testing.T, repo pg.Repository, authorID int64) int64 {
+	t.Helper()
+	counts, err := repo.CountBooksByAuthorIDs(context.Background(), []int64{authorID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) == 0 {
+		return 0
+	}
+	return counts[0].Count
+}
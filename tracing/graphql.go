@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/handler"
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen" // update the username
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type spanKey int
+
+const (
+	parsingSpanKey spanKey = iota
+	validationSpanKey
+	fieldSpanKey
+)
+
+// HandlerOptions returns the options instrumenting the GraphQL handler.
+func HandlerOptions() []handler.Option {
+	return []handler.Option{handler.Tracer(graphqlTracer{})}
+}
+
+// graphqlTracer implements graphql.Tracer. The parsing and validation spans
+// are kept out of the active span of the context, as gqlgen keeps using the
+// context they were started with after they ended.
+type graphqlTracer struct{}
+
+var _ graphql.Tracer = graphqlTracer{}
+
+func (graphqlTracer) StartOperationParsing(ctx context.Context) context.Context {
+	_, span := tracer().Start(ctx, "graphql.parse")
+	return context.WithValue(ctx, parsingSpanKey, span)
+}
+
+func (graphqlTracer) EndOperationParsing(ctx context.Context) {
+	if span, ok := ctx.Value(parsingSpanKey).(trace.Span); ok {
+		span.End()
+	}
+}
+
+func (graphqlTracer) StartOperationValidation(ctx context.Context) context.Context {
+	_, span := tracer().Start(ctx, "graphql.validate")
+	return context.WithValue(ctx, validationSpanKey, span)
+}
+
+func (graphqlTracer) EndOperationValidation(ctx context.Context) {
+	if span, ok := ctx.Value(validationSpanKey).(trace.Span); ok {
+		span.End()
+	}
+}
+
+func (graphqlTracer) StartOperationExecution(ctx context.Context) context.Context {
+	name := gqlgen.OperationName(graphql.GetRequestContext(ctx))
+	ctx, _ = tracer().Start(ctx, "graphql.execute "+name,
+		trace.WithAttributes(attribute.String("graphql.operation.name", name)),
+	)
+	return ctx
+}
+
+func (graphqlTracer) EndOperationExecution(ctx context.Context) {
+	span := trace.SpanFromContext(ctx)
+	if n := len(graphql.GetRequestContext(ctx).Errors); n > 0 {
+		span.SetAttributes(attribute.Int("graphql.errors", n))
+		span.SetStatus(codes.Error, "operation returned errors")
+	}
+	span.End()
+}
+
+// fieldSpan holds the span of a field, which is only started for fields with
+// a resolver method.
+type fieldSpan struct {
+	span trace.Span
+}
+
+func (graphqlTracer) StartFieldExecution(ctx context.Context, field graphql.CollectedField) context.Context {
+	return context.WithValue(ctx, fieldSpanKey, &fieldSpan{})
+}
+
+func (graphqlTracer) StartFieldResolverExecution(ctx context.Context, rc *graphql.ResolverContext) context.Context {
+	if !rc.IsMethod {
+		return ctx
+	}
+	ctx, span := tracer().Start(ctx, rc.Object+"."+rc.Field.Name,
+		trace.WithAttributes(
+			attribute.String("graphql.field.path", pathString(rc.Path())),
+		),
+	)
+	if fs, ok := ctx.Value(fieldSpanKey).(*fieldSpan); ok {
+		fs.span = span
+	}
+	return ctx
+}
+
+func (graphqlTracer) StartFieldChildExecution(ctx context.Context) context.Context {
+	return ctx
+}
+
+func (graphqlTracer) EndFieldExecution(ctx context.Context) {
+	if fs, ok := ctx.Value(fieldSpanKey).(*fieldSpan); ok && fs.span != nil {
+		fs.span.End()
+	}
+}
+
+func pathString(path []interface{}) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = fmt.Sprint(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// BatchObserver starts a span for every batch fetched by the dataloaders. It
+// implements dataloaders.BatchObserver.
+type BatchObserver struct{}
+
+// StartBatch implements dataloaders.BatchObserver.
+func (BatchObserver) StartBatch(ctx context.Context, loader string, keys int) (context.Context, func(error)) {
+	ctx, span := tracer().Start(ctx, "dataloader "+loader,
+		trace.WithAttributes(
+			attribute.String("dataloader.name", loader),
+			attribute.Int("dataloader.keys", keys),
+		),
+	)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
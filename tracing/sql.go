@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/pg" // update the username
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DBTX is a pg.DBTXMiddleware starting a span for every SQL statement.
+func DBTX(next pg.DBTX) pg.DBTX {
+	return &dbtx{next: next}
+}
+
+type dbtx struct {
+	next pg.DBTX
+}
+
+func (d *dbtx) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "sql "+pg.QueryName(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatementKey.String(query),
+		),
+	)
+}
+
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (d *dbtx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := d.start(ctx, query)
+	res, err := d.next.ExecContext(ctx, query, args...)
+	end(span, err)
+	return res, err
+}
+
+func (d *dbtx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.next.PrepareContext(ctx, query)
+}
+
+func (d *dbtx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := d.start(ctx, query)
+	rows, err := d.next.QueryContext(ctx, query, args...)
+	end(span, err)
+	return rows, err
+}
+
+func (d *dbtx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := d.start(ctx, query)
+	row := d.next.QueryRowContext(ctx, query, args...)
+	end(span, row.Err())
+	return row
+}
@@ -0,0 +1,235 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/gqlgen-sqlc-example/auth"        // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/dataloaders" // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/gqlgen"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/memory"      // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/pg"          // update the username
+	"github.com/fwojciec/gqlgen-sqlc-example/tracing"     // update the username
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record installs a tracer provider recording the ended spans in memory.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return sr
+}
+
+// find returns the ended span named name.
+func find(t *testing.T, sr *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+	var names []string
+	for _, span := range sr.Ended() {
+		if span.Name() == name {
+			return span
+		}
+		names = append(names, span.Name())
+	}
+	t.Fatalf("no span %q among %q", name, names)
+	return nil
+}
+
+// attr returns the value of the attribute key of span.
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+// checkParent checks that child is a child span of parent.
+func checkParent(t *testing.T, child, parent sdktrace.ReadOnlySpan) {
+	t.Helper()
+	if child.Parent().SpanID() != parent.SpanContext().SpanID() || child.SpanContext().TraceID() != parent.SpanContext().TraceID() {
+		t.Errorf("span %s is not a child of %s", child.Name(), parent.Name())
+	}
+}
+
+// serve executes query with h as an admin, wrapped in the tracing
+// middleware.
+func serve(t *testing.T, repo pg.Repository, query string) string {
+	t.Helper()
+	cfg := dataloaders.Config{Wait: time.Millisecond, Observer: tracing.BatchObserver{}}
+	options := append(cfg.HandlerOptions(gqlgen.Schema()), tracing.HandlerOptions()...)
+	h := gqlgen.NewHandler(repo, dataloaders.NewRetriever(), memory.NewBroker(), gqlgen.Limits{}, options...)
+	h = tracing.Middleware(dataloaders.Middleware(repo, cfg)(h))
+	body, _ := json.Marshal(map[string]string{"query": query})
+	r := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	admin := &auth.Principal{Subject: "admin", Roles: []auth.Role{auth.RoleAdmin}}
+	r = r.WithContext(auth.WithPrincipal(r.Context(), admin))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Body.String()
+}
+
+func TestResolverSpans(t *testing.T) {
+	sr := record(t)
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	agent, err := repo.CreateAgent(ctx, pg.CreateAgentParams{Name: "Agent", Email: "agent@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateAuthor(ctx, pg.CreateAuthorParams{Name: "Author", AgentID: agent.ID}); err != nil {
+		t.Fatal(err)
+	}
+	body := serve(t, repo, `query ListAgents { agents { edges { node { name authors { totalCount } } } } }`)
+	if strings.Contains(body, `"errors"`) {
+		t.Fatalf("response %s, want no errors", body)
+	}
+
+	httpSpan := find(t, sr, "HTTP POST /query")
+	if httpSpan.SpanKind() != trace.SpanKindServer || attr(httpSpan, "http.status_code").AsInt64() != http.StatusOK {
+		t.Errorf("HTTP span %v %v, want a server span with status 200", httpSpan.SpanKind(), httpSpan.Attributes())
+	}
+	for _, name := range []string{"graphql.parse", "graphql.validate"} {
+		checkParent(t, find(t, sr, name), httpSpan)
+	}
+	execute := find(t, sr, "graphql.execute ListAgents")
+	checkParent(t, execute, httpSpan)
+	if got := attr(execute, "graphql.operation.name").AsString(); got != "ListAgents" {
+		t.Errorf("graphql.operation.name = %q, want ListAgents", got)
+	}
+	if execute.Status().Code == codes.Error {
+		t.Errorf("execute span status %+v, want no error", execute.Status())
+	}
+
+	// the spans of the resolvers are nested as the fields
+	agents := find(t, sr, "Query.agents")
+	checkParent(t, agents, execute)
+	if got := attr(agents, "graphql.field.path").AsString(); got != "agents" {
+		t.Errorf("Query.agents path = %q, want agents", got)
+	}
+	authors := find(t, sr, "Agent.authors")
+	if got := attr(authors, "graphql.field.path").AsString(); got != "agents.edges.0.node.authors" {
+		t.Errorf("Agent.authors path = %q, want agents.edges.0.node.authors", got)
+	}
+	if authors.SpanContext().TraceID() != execute.SpanContext().TraceID() {
+		t.Error("Agent.authors is not in the trace of the operation")
+	}
+	// the fields without a resolver method have no span
+	for _, span := range sr.Ended() {
+		if span.Name() == "Agent.name" {
+			t.Error("got a span for Agent.name, want none")
+		}
+	}
+}
+
+func TestOperationErrorSpan(t *testing.T) {
+	sr := record(t)
+	serve(t, memory.NewRepository(), `query GetAgent { agent(id: "!!!") { name } }`)
+	execute := find(t, sr, "graphql.execute GetAgent")
+	if execute.Status().Code != codes.Error || attr(execute, "graphql.errors").AsInt64() != 1 {
+		t.Errorf("execute span %+v %v, want an error status and 1 error", execute.Status(), execute.Attributes())
+	}
+}
+
+// The SQL statements are traced within the spans of the resolvers loading
+// them.
+func TestSQLSpans(t *testing.T) {
+	sr := record(t)
+	db := sql.OpenDB(connector{})
+	defer db.Close()
+	serve(t, pg.NewRepository(db, tracing.DBTX), `query ListAgents { agents { edges { node { name } } } }`)
+
+	agents := find(t, sr, "Query.agents")
+	var stmt sdktrace.ReadOnlySpan
+	for _, span := range sr.Ended() {
+		if strings.HasPrefix(span.Name(), "sql ") {
+			stmt = span
+			break
+		}
+	}
+	if stmt == nil {
+		t.Fatal("no SQL span")
+	}
+	checkParent(t, stmt, agents)
+	if stmt.SpanKind() != trace.SpanKindClient {
+		t.Errorf("SQL span kind = %v, want client", stmt.SpanKind())
+	}
+	if got := attr(stmt, "db.system").AsString(); got != "postgresql" {
+		t.Errorf("db.system = %q, want postgresql", got)
+	}
+	query := attr(stmt, "db.statement").AsString()
+	if name := strings.TrimPrefix(stmt.Name(), "sql "); !strings.HasPrefix(query, "-- name: "+name+" ") {
+		t.Errorf("span %s has statement %q, want the statement it is named after", stmt.Name(), query)
+	}
+}
+
+func TestSQLErrorSpan(t *testing.T) {
+	sr := record(t)
+	db := sql.OpenDB(connector{})
+	defer db.Close()
+	query := "-- name: DeleteAgent :exec\nDELETE FROM agents WHERE id = $1"
+	if _, err := tracing.DBTX(db).ExecContext(context.Background(), query, 1); !errors.Is(err, errExec) {
+		t.Fatalf("ExecContext() = %v, want %v", err, errExec)
+	}
+	span := find(t, sr, "sql DeleteAgent")
+	if got := attr(span, "db.statement").AsString(); got != query {
+		t.Errorf("db.statement = %q, want %q", got, query)
+	}
+	if span.Status().Code != codes.Error || span.Status().Description != errExec.Error() {
+		t.Errorf("status %+v, want error %v", span.Status(), errExec)
+	}
+	if events := span.Events(); len(events) != 1 || events[0].Name != "exception" {
+		t.Errorf("events %+v, want the recorded error", events)
+	}
+}
+
+// errExec is returned by the statements executed with connector.
+var errExec = errors.New("exec failed")
+
+// connector is a driver.Connector whose queries return no rows and whose
+// statements fail with errExec.
+type connector struct{}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return conn{}, nil }
+func (c connector) Driver() driver.Driver                        { return nil }
+
+type conn struct{}
+
+func (conn) Prepare(query string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (conn) Close() error                              { return nil }
+func (conn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func (conn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return rows{}, nil
+}
+
+func (conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return nil, errExec
+}
+
+type rows struct{}
+
+func (rows) Columns() []string              { return nil }
+func (rows) Close() error                   { return nil }
+func (rows) Next(dest []driver.Value) error { return io.EOF }